@@ -0,0 +1,94 @@
+package uuidv8country
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/google/uuid"
+)
+
+// base62Alphabet is ordered by ASCII value, so fixed-width encodings sort in
+// the same order as the UUID bytes.
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Base62Length is the length of every string produced by ToBase62.
+const Base62Length = 22
+
+// ToBase62 encodes u as a 22-character, URL-safe base62 string.
+//
+// The output is left-padded with '0' to a fixed width, so encoded UUIDs keep
+// their byte-wise (and therefore chronological) order when compared as
+// strings.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Netherlands)
+//	short := ToBase62(u)
+//	fmt.Println(len(short)) // Output: 22
+func ToBase62(u uuid.UUID) string {
+	hi := binary.BigEndian.Uint64(u[0:8])
+	lo := binary.BigEndian.Uint64(u[8:16])
+
+	var buf [Base62Length]byte
+	for i := Base62Length - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, 62)
+		lo, rem = bits.Div64(rem, lo, 62)
+		buf[i] = base62Alphabet[rem]
+	}
+
+	return string(buf[:])
+}
+
+// FromBase62 decodes a string produced by ToBase62.
+//
+// Returns an error if s is not exactly 22 characters of the base62 alphabet, if
+// it encodes a value wider than 128 bits, or if the decoded UUID does not carry
+// version 8 and the RFC 4122 variant.
+func FromBase62(s string) (uuid.UUID, error) {
+	if len(s) != Base62Length {
+		return uuid.Nil, fmt.Errorf("invalid base62 length: %d characters, expected %d", len(s), Base62Length)
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		digit, ok := base62Digit(s[i])
+		if !ok {
+			return uuid.Nil, fmt.Errorf("invalid base62 character %q at position %d", s[i], i)
+		}
+
+		// (hi, lo) = (hi, lo)*62 + digit, rejecting anything past 128 bits
+		overflow, hi62 := bits.Mul64(hi, 62)
+		carry, lo62 := bits.Mul64(lo, 62)
+		lo62, carryDigit := bits.Add64(lo62, uint64(digit), 0)
+		hi62, carryHi := bits.Add64(hi62, carry, carryDigit)
+		if overflow != 0 || carryHi != 0 {
+			return uuid.Nil, fmt.Errorf("base62 value %q overflows 128 bits", s)
+		}
+		hi, lo = hi62, lo62
+	}
+
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[0:8], hi)
+	binary.BigEndian.PutUint64(u[8:16], lo)
+
+	if err := checkVersionVariant(u); err != nil {
+		return uuid.Nil, err
+	}
+
+	return u, nil
+}
+
+// base62Digit returns the value of c in base62Alphabet.
+func base62Digit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'A' && c <= 'Z':
+		return c - 'A' + 10, true
+	case c >= 'a' && c <= 'z':
+		return c - 'a' + 36, true
+	}
+	return 0, false
+}
@@ -0,0 +1,92 @@
+package uuidv8country
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestBase62_RoundTrip(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		u, err := CountryUUIDv8(countries.Netherlands)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+
+		short := ToBase62(u)
+		if len(short) != Base62Length {
+			t.Fatalf("ToBase62() length = %d, expected %d", len(short), Base62Length)
+		}
+
+		decoded, err := FromBase62(short)
+		if err != nil {
+			t.Fatalf("FromBase62(%q) error = %v", short, err)
+		}
+		if decoded != u {
+			t.Fatalf("FromBase62(%q) = %s, expected %s", short, decoded, u)
+		}
+		if err := Validate(decoded); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+	}
+}
+
+func TestBase62_Extremes(t *testing.T) {
+	var zero uuid.UUID
+	if got := ToBase62(zero); got != strings.Repeat("0", Base62Length) {
+		t.Errorf("ToBase62(zero) = %q", got)
+	}
+
+	var max uuid.UUID
+	for i := range max {
+		max[i] = 0xff
+	}
+	if got := ToBase62(max); got != "7n42DGM5Tflk9n8mt7Fhc7" {
+		t.Errorf("ToBase62(max) = %q", got)
+	}
+}
+
+func TestBase62_PreservesOrder(t *testing.T) {
+	us, err := CountryUUIDv8Batch(countries.Netherlands, 100)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8Batch() error = %v", err)
+	}
+	SortByTime(us)
+
+	encoded := make([]string, len(us))
+	for i, u := range us {
+		encoded[i] = ToBase62(u)
+	}
+
+	if !sort.StringsAreSorted(encoded) {
+		t.Error("Base62 encodings do not sort in UUID order")
+	}
+}
+
+func TestFromBase62_Invalid(t *testing.T) {
+	valid, _ := CountryUUIDv8(countries.Netherlands)
+	short := ToBase62(valid)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Empty", ""},
+		{"TooShort", short[1:]},
+		{"TooLong", short + "0"},
+		{"BadCharacter", "-" + short[1:]},
+		{"Overflow", "zzzzzzzzzzzzzzzzzzzzzz"},
+		{"Version4", ToBase62(uuid.New())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromBase62(tt.input); err == nil {
+				t.Errorf("FromBase62(%q) should return error", tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package uuidv8country
+
+import (
+	"fmt"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// IndexError reports a failure for a single UUID within a slice passed to one
+// of the bulk helpers.
+type IndexError struct {
+	// Index is the position of the offending UUID in the input slice.
+	Index int
+
+	// UUID is the offending UUID.
+	UUID uuid.UUID
+
+	// Err is the underlying error, such as one returned by ExtractCountry.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("uuid %s at index %d: %v", e.UUID, e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error so errors.Is and errors.As see through it.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// ExtractCountries extracts the country code from every UUID in us.
+//
+// The result is parallel to us. Extraction stops at the first UUID that
+// ExtractCountry rejects and the returned *IndexError identifies its position.
+//
+// Example:
+//
+//	codes, err := ExtractCountries(page)
+//	var indexErr *IndexError
+//	if errors.As(err, &indexErr) {
+//		log.Printf("row %d is not a country UUID", indexErr.Index)
+//	}
+func ExtractCountries(us []uuid.UUID) ([]countries.CountryCode, error) {
+	result := make([]countries.CountryCode, len(us))
+
+	if _, err := ExtractCountriesInto(result, us); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ExtractCountriesInto is like ExtractCountries but writes the country codes
+// into dst instead of allocating a new slice, so a decode loop can reuse one
+// buffer across calls. It does not allocate unless a UUID is rejected.
+//
+// It returns the number of elements of dst filled. On success that is
+// len(src); when a UUID is rejected it is the index of that UUID, reported by
+// the returned *IndexError.
+//
+// Example:
+//
+//	buf := make([]countries.CountryCode, pageSize)
+//	for page := range pages {
+//		n, err := ExtractCountriesInto(buf, page)
+//		...
+//		process(buf[:n])
+//	}
+//
+// Returns an error without writing anything if dst is shorter than src.
+func ExtractCountriesInto(dst []countries.CountryCode, src []uuid.UUID) (int, error) {
+	if len(dst) < len(src) {
+		return 0, fmt.Errorf("destination too short: %d elements for %d UUIDs", len(dst), len(src))
+	}
+
+	// Decode inline rather than through ExtractCountry: indexing instead of
+	// ranging by value avoids copying every UUID, and reslicing dst to the
+	// length of src lets the compiler drop the bounds checks on it. This
+	// measures about three times as fast as calling ExtractCountry in a loop
+	// (BenchmarkExtractCountriesBatch against BenchmarkExtractCountriesLoop),
+	// which assumes valid input and holds only while the per-UUID work stays
+	// a few byte reads; the first rejected UUID goes through ExtractCountry
+	// for its error.
+	dst = dst[:len(src)]
+	for i := range src {
+		u := &src[i]
+
+		// Continent-only UUIDs carry the region flag, which requires the
+		// extension bit, in byte 11
+		if u[6]>>4 != 8 || u[8]&extensionBit != 0 && u[OptionsByteOffset]&RegionFlag != 0 {
+			_, err := ExtractCountry(*u)
+			return i, &IndexError{Index: i, UUID: *u, Err: err}
+		}
+
+		dst[i] = countries.CountryCode(uint32(u[8]&0x0f)<<16 | uint32(u[9])<<8 | uint32(u[10]))
+	}
+
+	return len(src), nil
+}
+
+// ExtractCountriesLenient is like ExtractCountries but does not stop at
+// invalid UUIDs.
+//
+// Positions holding an invalid UUID are set to countries.Unknown in the result,
+// and one *IndexError per invalid UUID is returned in input order. The error
+// slice is nil when every UUID was valid.
+func ExtractCountriesLenient(us []uuid.UUID) ([]countries.CountryCode, []*IndexError) {
+	result := make([]countries.CountryCode, len(us))
+	var errs []*IndexError
+
+	for i, u := range us {
+		country, err := ExtractCountry(u)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, UUID: u, Err: err})
+			continue
+		}
+		result[i] = country
+	}
+
+	return result, errs
+}
+
+// GroupByCountry buckets us by embedded country, keeping the input order within
+// each bucket.
+//
+// Grouping stops at the first UUID that ExtractCountry rejects and the
+// returned *IndexError identifies its position.
+//
+// Example:
+//
+//	groups, err := GroupByCountry(ids)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(len(groups[countries.Japan]))
+func GroupByCountry(us []uuid.UUID) (map[countries.CountryCode][]uuid.UUID, error) {
+	groups := make(map[countries.CountryCode][]uuid.UUID)
+
+	for i, u := range us {
+		country, err := ExtractCountry(u)
+		if err != nil {
+			return nil, &IndexError{Index: i, UUID: u, Err: err}
+		}
+		groups[country] = append(groups[country], u)
+	}
+
+	return groups, nil
+}
+
+// GroupByCountryLenient is like GroupByCountry but does not stop at invalid
+// UUIDs. They are left out of the groups and returned as one *IndexError each
+// in input order, which makes it easy to report data quality problems. The
+// error slice is nil when every UUID was valid.
+func GroupByCountryLenient(us []uuid.UUID) (map[countries.CountryCode][]uuid.UUID, []*IndexError) {
+	groups := make(map[countries.CountryCode][]uuid.UUID)
+	var errs []*IndexError
+
+	for i, u := range us {
+		country, err := ExtractCountry(u)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, UUID: u, Err: err})
+			continue
+		}
+		groups[country] = append(groups[country], u)
+	}
+
+	return groups, errs
+}
+
+// CountryHistogram counts the UUIDs in us per embedded country, for data
+// audits over large slices. UUIDs that ExtractCountry rejects are skipped and
+// counted separately in the second result.
+//
+// Unlike GroupByCountry it keeps no UUIDs, so it allocates nothing beyond the
+// map, however long us is.
+//
+// Example:
+//
+//	counts, invalid, _ := CountryHistogram(ids)
+//	fmt.Printf("%d from Japan, %d invalid\n", counts[countries.Japan], invalid)
+//
+// The error result is reserved for future use and currently always nil.
+func CountryHistogram(us []uuid.UUID) (map[countries.CountryCode]int, int, error) {
+	counts := make(map[countries.CountryCode]int)
+	invalid := 0
+
+	for _, u := range us {
+		country, err := ExtractCountry(u)
+		if err != nil {
+			invalid++
+			continue
+		}
+		counts[country]++
+	}
+
+	return counts, invalid, nil
+}
+
+// InvalidEntry is a UUID rejected by Partition along with the reason.
+type InvalidEntry struct {
+	// Index is the position of the UUID in the input slice.
+	Index int
+
+	// UUID is the rejected UUID.
+	UUID uuid.UUID
+
+	// Err is the error Validate returned for it.
+	Err error
+}
+
+// Partition splits us into the UUIDs that pass Validate and those that do not,
+// each rejected one with its error, for data-quality reporting in ETL jobs.
+// Both results keep the order of us.
+//
+// The valid slice is allocated once with room for all of us, so valid UUIDs
+// cost no allocation of their own; only rejected ones allocate, for their
+// entry and error.
+//
+// Example:
+//
+//	valid, invalid := Partition(rows)
+//	for _, e := range invalid {
+//		log.Printf("row %d: %v", e.Index, e.Err)
+//	}
+//	load(valid)
+func Partition(us []uuid.UUID) (valid []uuid.UUID, invalid []InvalidEntry) {
+	valid = make([]uuid.UUID, 0, len(us))
+
+	for i, u := range us {
+		if err := Validate(u); err != nil {
+			invalid = append(invalid, InvalidEntry{Index: i, UUID: u, Err: err})
+			continue
+		}
+		valid = append(valid, u)
+	}
+
+	return valid, invalid
+}
@@ -0,0 +1,275 @@
+package uuidv8country
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestExtractCountries(t *testing.T) {
+	want := []countries.CountryCode{countries.Chile, countries.Peru, countries.Unknown, countries.Egypt}
+
+	us := make([]uuid.UUID, len(want))
+	for i, country := range want {
+		u, err := CountryUUIDv8(country)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+		us[i] = u
+	}
+
+	got, err := ExtractCountries(us)
+	if err != nil {
+		t.Fatalf("ExtractCountries() error = %v", err)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ExtractCountries()[%d] = %v, expected %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestExtractCountries_FailFast(t *testing.T) {
+	valid, _ := CountryUUIDv8(countries.Chile)
+	us := []uuid.UUID{valid, valid, uuid.New(), uuid.New()}
+
+	got, err := ExtractCountries(us)
+	if got != nil {
+		t.Errorf("ExtractCountries() = %v, expected nil on error", got)
+	}
+
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) {
+		t.Fatalf("ExtractCountries() error = %v, expected *IndexError", err)
+	}
+	if indexErr.Index != 2 {
+		t.Errorf("IndexError.Index = %d, expected 2", indexErr.Index)
+	}
+	if !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCountries() error = %v, expected to wrap ErrWrongVersion", err)
+	}
+}
+
+func TestExtractCountriesLenient(t *testing.T) {
+	chile, _ := CountryUUIDv8(countries.Chile)
+	peru, _ := CountryUUIDv8(countries.Peru)
+	us := []uuid.UUID{uuid.New(), chile, uuid.Nil, peru}
+
+	got, errs := ExtractCountriesLenient(us)
+
+	want := []countries.CountryCode{countries.Unknown, countries.Chile, countries.Unknown, countries.Peru}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ExtractCountriesLenient()[%d] = %v, expected %v", i, got[i], want[i])
+		}
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("ExtractCountriesLenient() returned %d errors, expected 2", len(errs))
+	}
+	if errs[0].Index != 0 || errs[1].Index != 2 {
+		t.Errorf("error indexes = %d, %d, expected 0, 2", errs[0].Index, errs[1].Index)
+	}
+
+	if _, errs := ExtractCountriesLenient([]uuid.UUID{chile}); errs != nil {
+		t.Errorf("ExtractCountriesLenient() errors = %v, expected nil", errs)
+	}
+}
+
+func TestExtractCountriesInto(t *testing.T) {
+	want := []countries.CountryCode{countries.Chile, countries.Peru, countries.Egypt}
+	us, _ := CountryUUIDv8Batch(countries.Chile, len(want))
+	for i, country := range want {
+		us[i], _ = WithCountry(us[i], country)
+	}
+
+	dst := make([]countries.CountryCode, 5)
+	n, err := ExtractCountriesInto(dst, us)
+	if err != nil {
+		t.Fatalf("ExtractCountriesInto() error = %v", err)
+	}
+	if n != len(want) {
+		t.Errorf("ExtractCountriesInto() = %d, expected %d", n, len(want))
+	}
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Errorf("dst[%d] = %v, expected %v", i, dst[i], want[i])
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ExtractCountriesInto(dst, us)
+	})
+	if allocs != 0 {
+		t.Errorf("ExtractCountriesInto() allocs = %v, expected 0", allocs)
+	}
+}
+
+func TestExtractCountriesInto_Errors(t *testing.T) {
+	valid, _ := CountryUUIDv8(countries.Chile)
+
+	if _, err := ExtractCountriesInto(make([]countries.CountryCode, 1), []uuid.UUID{valid, valid}); err == nil {
+		t.Error("ExtractCountriesInto() should return error for a short destination")
+	}
+
+	dst := make([]countries.CountryCode, 3)
+	n, err := ExtractCountriesInto(dst, []uuid.UUID{valid, uuid.New(), valid})
+	if n != 1 {
+		t.Errorf("ExtractCountriesInto() = %d, expected 1", n)
+	}
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 1 {
+		t.Errorf("ExtractCountriesInto() error = %v, expected *IndexError at index 1", err)
+	}
+}
+
+func TestExtractCountriesInto_MatchesExtractCountry(t *testing.T) {
+	withOptions, _ := CountryUUIDv8(countries.Chile, WithNodeID(1), WithChecksum())
+	continent, _ := ContinentUUIDv8(countries.RegionSA)
+
+	wrongVariant, _ := CountryUUIDv8(countries.Chile)
+	wrongVariant[8] &= 0x3f
+
+	// Extension bit without the region flag, and region flag bit in random data
+	extensionOnly, _ := CountryUUIDv8(countries.Chile, WithNodeID(2))
+	randomByte11, _ := CountryUUIDv8(countries.Chile)
+	randomByte11[OptionsByteOffset] = 0xff
+
+	for _, u := range []uuid.UUID{withOptions, continent, wrongVariant, extensionOnly, randomByte11, uuid.New(), uuid.Nil} {
+		want, wantErr := ExtractCountry(u)
+
+		dst := make([]countries.CountryCode, 1)
+		_, err := ExtractCountriesInto(dst, []uuid.UUID{u})
+		if (err == nil) != (wantErr == nil) || wantErr != nil && !errors.Is(err, wantErr) {
+			t.Errorf("ExtractCountriesInto(%s) error = %v, expected %v", u, err, wantErr)
+		}
+		if wantErr == nil && dst[0] != want {
+			t.Errorf("ExtractCountriesInto(%s) = %v, expected %v", u, dst[0], want)
+		}
+	}
+}
+
+func TestGroupByCountry(t *testing.T) {
+	chile1, _ := CountryUUIDv8(countries.Chile)
+	chile2, _ := CountryUUIDv8(countries.Chile)
+	peru, _ := CountryUUIDv8(countries.Peru)
+
+	groups, err := GroupByCountry([]uuid.UUID{chile1, peru, chile2})
+	if err != nil {
+		t.Fatalf("GroupByCountry() error = %v", err)
+	}
+
+	if len(groups) != 2 {
+		t.Errorf("GroupByCountry() returned %d groups, expected 2", len(groups))
+	}
+	if got := groups[countries.Chile]; len(got) != 2 || got[0] != chile1 || got[1] != chile2 {
+		t.Errorf("GroupByCountry()[Chile] = %v, expected [%s %s]", got, chile1, chile2)
+	}
+	if got := groups[countries.Peru]; len(got) != 1 || got[0] != peru {
+		t.Errorf("GroupByCountry()[Peru] = %v, expected [%s]", got, peru)
+	}
+
+	groups, err = GroupByCountry([]uuid.UUID{chile1, uuid.New()})
+	if groups != nil {
+		t.Errorf("GroupByCountry() = %v, expected nil on error", groups)
+	}
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 1 {
+		t.Errorf("GroupByCountry() error = %v, expected *IndexError at index 1", err)
+	}
+}
+
+func TestGroupByCountryLenient(t *testing.T) {
+	chile, _ := CountryUUIDv8(countries.Chile)
+	peru, _ := CountryUUIDv8(countries.Peru)
+
+	groups, errs := GroupByCountryLenient([]uuid.UUID{uuid.Nil, chile, uuid.New(), peru})
+
+	if len(groups) != 2 || len(groups[countries.Chile]) != 1 || len(groups[countries.Peru]) != 1 {
+		t.Errorf("GroupByCountryLenient() = %v, expected one Chile and one Peru UUID", groups)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("GroupByCountryLenient() returned %d errors, expected 2", len(errs))
+	}
+	if errs[0].Index != 0 || errs[1].Index != 2 {
+		t.Errorf("error indexes = %d, %d, expected 0, 2", errs[0].Index, errs[1].Index)
+	}
+
+	if _, errs := GroupByCountryLenient([]uuid.UUID{chile}); errs != nil {
+		t.Errorf("GroupByCountryLenient() errors = %v, expected nil", errs)
+	}
+}
+
+func TestCountryHistogram(t *testing.T) {
+	chile, _ := CountryUUIDv8Batch(countries.Chile, 5)
+	peru, _ := CountryUUIDv8Batch(countries.Peru, 2)
+	us := append(append(chile, peru...), uuid.New(), uuid.Nil)
+
+	counts, invalid, err := CountryHistogram(us)
+	if err != nil {
+		t.Fatalf("CountryHistogram() error = %v", err)
+	}
+	if counts[countries.Chile] != 5 || counts[countries.Peru] != 2 || len(counts) != 2 {
+		t.Errorf("CountryHistogram() = %v, expected Chile:5 Peru:2", counts)
+	}
+	if invalid != 2 {
+		t.Errorf("CountryHistogram() invalid = %d, expected 2", invalid)
+	}
+
+	// Allocations depend on the number of countries, not of UUIDs
+	many := make([]uuid.UUID, 0, 10000)
+	for i := 0; i < 10000/len(us); i++ {
+		many = append(many, us...)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _, _ = CountryHistogram(many)
+	})
+	if allocs > 5 {
+		t.Errorf("CountryHistogram() allocs = %v for %d UUIDs, expected a handful", allocs, len(many))
+	}
+}
+
+func TestPartition(t *testing.T) {
+	chile, _ := CountryUUIDv8Batch(countries.Chile, 3)
+	unknown, _ := CountryUUIDv8(countries.Unknown)
+	v4 := uuid.New()
+	us := []uuid.UUID{chile[0], v4, chile[1], unknown, uuid.Nil, chile[2]}
+
+	valid, invalid := Partition(us)
+
+	if len(valid) != 3 || valid[0] != chile[0] || valid[1] != chile[1] || valid[2] != chile[2] {
+		t.Errorf("Partition() valid = %v, expected %v", valid, chile)
+	}
+
+	want := []struct {
+		index int
+		err   error
+	}{
+		{1, ErrWrongVersion},
+		{3, ErrUnknownCountry},
+		{4, ErrZeroUUID},
+	}
+	if len(invalid) != len(want) {
+		t.Fatalf("Partition() invalid = %v, expected %d entries", invalid, len(want))
+	}
+	for i, w := range want {
+		e := invalid[i]
+		if e.Index != w.index || e.UUID != us[w.index] || !errors.Is(e.Err, w.err) {
+			t.Errorf("Partition() invalid[%d] = %+v, expected index %d with %v", i, e, w.index, w.err)
+		}
+	}
+}
+
+func TestPartition_Allocations(t *testing.T) {
+	us, _ := CountryUUIDv8Batch(countries.Chile, 1000)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_, _ = Partition(us)
+	})
+	if allocs > 1 {
+		t.Errorf("Partition() allocs = %v for %d valid UUIDs, expected 1", allocs, len(us))
+	}
+}
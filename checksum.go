@@ -0,0 +1,35 @@
+package uuidv8country
+
+import "github.com/google/uuid"
+
+// VerifyChecksum reports whether u is a UUID v8 generated with WithChecksum
+// whose checksum still matches its other 15 bytes.
+//
+// It returns false for UUIDs generated without WithChecksum, so it can also be
+// used to tell apart UUIDs from this package and foreign v8 UUIDs, the latter
+// passing by accident only about once in 1000: they need the extension bit
+// (1/2), the checksum flag (1/2) and a matching CRC-8 (1/256).
+func VerifyChecksum(u uuid.UUID) bool {
+	if checkVersionVariant(u) != nil || optionFlags(u)&ChecksumFlag == 0 {
+		return false
+	}
+
+	return u[ChecksumByteOffset] == crc8(u[:ChecksumByteOffset])
+}
+
+// crc8 computes the CRC-8 of data with the polynomial x^8 + x^2 + x + 1, as
+// used by ATM HEC and SMBus.
+func crc8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
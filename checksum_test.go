@@ -0,0 +1,86 @@
+package uuidv8country
+
+import (
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestCRC8(t *testing.T) {
+	// Standard check value for CRC-8 with polynomial 0x07
+	if got := crc8([]byte("123456789")); got != 0xf4 {
+		t.Errorf("crc8() = %#x, expected 0xf4", got)
+	}
+}
+
+func TestWithChecksum(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithChecksum()},
+		{WithChecksum(), WithNodeID(200)},
+	} {
+		u, err := CountryUUIDv8(countries.Ghana, opts...)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+
+		if !VerifyChecksum(u) {
+			t.Errorf("VerifyChecksum(%s) = false, expected true", u)
+		}
+		if err := Validate(u); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+		if country := MustExtractCountry(u); country != countries.Ghana {
+			t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Ghana)
+		}
+	}
+}
+
+func TestVerifyChecksum_MangledCharacter(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Ghana, WithChecksum())
+	s := u.String()
+
+	for i := range s {
+		if s[i] == '-' {
+			continue
+		}
+
+		for _, c := range "0123456789abcdef" {
+			if byte(c) == s[i] {
+				continue
+			}
+
+			mangled, err := uuid.Parse(s[:i] + string(c) + s[i+1:])
+			if err != nil {
+				t.Fatalf("uuid.Parse() error = %v", err)
+			}
+			if VerifyChecksum(mangled) {
+				t.Errorf("VerifyChecksum(%s) = true for mangled UUID", mangled)
+			}
+		}
+	}
+}
+
+func TestVerifyChecksum_NotSet(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Ghana)
+	u[ChecksumByteOffset] = crc8(u[:ChecksumByteOffset])
+	if VerifyChecksum(u) {
+		t.Error("VerifyChecksum() = true for UUID generated without WithChecksum")
+	}
+
+	if VerifyChecksum(uuid.New()) {
+		t.Error("VerifyChecksum() = true for a v4 UUID")
+	}
+}
+
+func TestVerifyChecksum_WithCountry(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Ghana, WithChecksum())
+
+	fixed, err := WithCountry(u, countries.Togo)
+	if err != nil {
+		t.Fatalf("WithCountry() error = %v", err)
+	}
+	if !VerifyChecksum(fixed) {
+		t.Error("VerifyChecksum() = false after WithCountry")
+	}
+}
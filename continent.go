@@ -0,0 +1,96 @@
+package uuidv8country
+
+import (
+	"fmt"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// ContinentUUIDv8 generates a continent-only UUID version 8 for when only the
+// region of origin is known, using the package's default generator.
+//
+// The region is stored in the country field as RegionCodeBase plus the region
+// code, a range reserved so that it never collides with a country code, and
+// the RegionFlag option flag is set. ExtractRegion returns the region, while
+// ExtractCountry reports ErrContinentOnly. Timestamp and ordering work exactly
+// as for country UUIDs.
+//
+// Example:
+//
+//	u, err := ContinentUUIDv8(countries.RegionEU)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	region, _ := ExtractRegion(u)
+//	fmt.Println(region) // Output: Europe
+//
+// Returns an error if region is not one of the regions listed by
+// countries.AllRegions, or if random number generation fails.
+func ContinentUUIDv8(region countries.RegionCode, opts ...Option) (uuid.UUID, error) {
+	return defaultGenerator.ContinentUUIDv8(region, opts...)
+}
+
+// ContinentUUIDv8 generates a continent-only UUID version 8 drawing random bits
+// from the generator's reader. See the package-level ContinentUUIDv8.
+func (g *Generator) ContinentUUIDv8(region countries.RegionCode, opts ...Option) (uuid.UUID, error) {
+	if !isRegion(region) {
+		return uuid.Nil, fmt.Errorf("unknown region code %d", int64(region))
+	}
+
+	tick, err := g.tickAt(g.clock.Now())
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// Copy rather than append into the caller's slice
+	opts = append(opts[:len(opts):len(opts)], func(o *options) {
+		o.flags |= RegionFlag
+	})
+
+	u, _, err := g.generate(countries.CountryCode(RegionCodeBase+region), tick, g.monotonic, opts)
+	return u, err
+}
+
+// ExtractRegion returns the UN M.49 region (continent) of a UUID v8: the
+// embedded region for continent-only UUIDs generated by ContinentUUIDv8, and
+// the region of the embedded country otherwise.
+//
+// Country UUIDs embedding countries.Unknown, or any code the countries package
+// does not recognize, yield countries.RegionUnknown.
+//
+// Returns an error if the UUID is not version 8, or if it is marked
+// continent-only but its country field lies outside the reserved region range.
+func ExtractRegion(u uuid.UUID) (countries.RegionCode, error) {
+	code, err := ExtractRawCode(u)
+	if err != nil {
+		return countries.RegionUnknown, err
+	}
+
+	if optionFlags(u)&RegionFlag == 0 {
+		return countries.CountryCode(code).Region(), nil
+	}
+
+	if code < RegionCodeBase {
+		return countries.RegionUnknown, fmt.Errorf("region field %d outside the reserved range", code)
+	}
+
+	return countries.RegionCode(code - RegionCodeBase), nil
+}
+
+// IsContinentOnly reports whether u is a continent-only UUID generated by
+// ContinentUUIDv8.
+func IsContinentOnly(u uuid.UUID) bool {
+	return checkVersionVariant(u) == nil && optionFlags(u)&RegionFlag != 0
+}
+
+// isRegion reports whether region is one of the continents of the countries
+// package.
+func isRegion(region countries.RegionCode) bool {
+	for _, r := range countries.AllRegions() {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
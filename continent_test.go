@@ -0,0 +1,104 @@
+package uuidv8country
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestContinentUUIDv8(t *testing.T) {
+	for _, region := range countries.AllRegions() {
+		t.Run(region.String(), func(t *testing.T) {
+			u, err := ContinentUUIDv8(region)
+			if err != nil {
+				t.Fatalf("ContinentUUIDv8() error = %v", err)
+			}
+
+			if Version(u) != 8 || !IsRFC4122Variant(u) {
+				t.Errorf("ContinentUUIDv8() = %s, expected version 8 and RFC 4122 variant", u)
+			}
+			if !IsContinentOnly(u) {
+				t.Error("IsContinentOnly() = false, expected true")
+			}
+
+			got, err := ExtractRegion(u)
+			if err != nil {
+				t.Fatalf("ExtractRegion() error = %v", err)
+			}
+			if got != region {
+				t.Errorf("ExtractRegion() = %v, expected %v", got, region)
+			}
+			if got, _ := ExtractContinent(u); got != region {
+				t.Errorf("ExtractContinent() = %v, expected %v", got, region)
+			}
+
+			if _, err := ExtractCountry(u); !errors.Is(err, ErrContinentOnly) {
+				t.Errorf("ExtractCountry() error = %v, expected ErrContinentOnly", err)
+			}
+			if HasKnownCountry(u) {
+				t.Error("HasKnownCountry() = true for a continent-only UUID")
+			}
+
+			// The reserved range is above every code of the countries package
+			raw, _ := ExtractRawCode(u)
+			if raw < RegionCodeBase || countries.CountryCode(raw).IsValid() {
+				t.Errorf("ExtractRawCode() = %d, expected an unused code from %d", raw, RegionCodeBase)
+			}
+		})
+	}
+}
+
+func TestContinentUUIDv8_Invalid(t *testing.T) {
+	for _, region := range []countries.RegionCode{countries.RegionUnknown, countries.RegionNone, 255} {
+		if _, err := ContinentUUIDv8(region); err == nil {
+			t.Errorf("ContinentUUIDv8(%d) should return error", region)
+		}
+	}
+}
+
+func TestContinentUUIDv8_Options(t *testing.T) {
+	u, err := ContinentUUIDv8(countries.RegionAF, WithStrictCountry(), WithChecksum(), WithNodeID(3))
+	if err != nil {
+		t.Fatalf("ContinentUUIDv8() error = %v", err)
+	}
+	if !VerifyChecksum(u) {
+		t.Error("VerifyChecksum() = false, expected true")
+	}
+	if node, _ := ExtractNodeID(u); node != 3 {
+		t.Errorf("ExtractNodeID() = %d, expected 3", node)
+	}
+	if region, _ := ExtractRegion(u); region != countries.RegionAF {
+		t.Errorf("ExtractRegion() = %v, expected %v", region, countries.RegionAF)
+	}
+}
+
+func TestExtractRegion_Country(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Kenya)
+	if IsContinentOnly(u) {
+		t.Error("IsContinentOnly() = true for a country UUID")
+	}
+	if region, err := ExtractRegion(u); err != nil || region != countries.RegionAF {
+		t.Errorf("ExtractRegion() = %v, %v, expected %v", region, err, countries.RegionAF)
+	}
+
+	if _, err := ExtractRegion(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractRegion() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestWithCountry_ContinentOnly(t *testing.T) {
+	u, _ := ContinentUUIDv8(countries.RegionSA)
+
+	fixed, err := WithCountry(u, countries.Colombia)
+	if err != nil {
+		t.Fatalf("WithCountry() error = %v", err)
+	}
+	if IsContinentOnly(fixed) {
+		t.Error("IsContinentOnly() = true after WithCountry")
+	}
+	if country := MustExtractCountry(fixed); country != countries.Colombia {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Colombia)
+	}
+}
@@ -0,0 +1,151 @@
+package uuidv8country
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// WithCountry returns a copy of u with the embedded country replaced by
+// country, keeping its timestamp and random bits. It is meant for correcting
+// stored UUIDs without losing their original creation time.
+//
+// Only the country bits are rewritten, plus the checksum if u was generated
+// WithChecksum, and the region flag is cleared if u was generated by
+// ContinentUUIDv8. The version and variant are set to 8
+// and RFC 4122 in case u lacks them, so the result always passes Validate for a
+// known country; GetTimestamp is unchanged for any UUID generated by this
+// package.
+//
+// Example:
+//
+//	fixed, err := WithCountry(u, countries.Austria)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(MustExtractCountry(fixed)) // Output: Austria
+//
+// Returns ErrInvalidCountryCode if country cannot be stored in the country
+// field.
+func WithCountry(u uuid.UUID, country countries.CountryCode) (uuid.UUID, error) {
+	if err := putCountry(&u, country); err != nil {
+		return uuid.Nil, err
+	}
+	return u, nil
+}
+
+// WithTimestamp returns a copy of u with the embedded creation time replaced by
+// t, keeping its country, optional fields and random bits. It is meant for
+// normalizing imported records whose time was wrong but whose country is
+// trusted, the counterpart of WithCountry.
+//
+// Both the millisecond timestamp and the sub-millisecond fraction are
+// rewritten, relative to the UNIX epoch like the package-level functions, plus
+// the checksum if u was generated WithChecksum. The version and variant are
+// set to 8 and RFC 4122. Since the fraction is no longer a counter, the
+// MonotonicFlag is cleared.
+//
+// Example:
+//
+//	fixed, err := WithTimestamp(u, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(GetTimestamp(fixed)) // Output: 2024-01-01 00:00:00 +0000 UTC
+//
+// Returns an error wrapping ErrTimestampOutOfRange if t is before the UNIX
+// epoch or after MaxTimestamp.
+func WithTimestamp(u uuid.UUID, t time.Time) (uuid.UUID, error) {
+	tick, err := tickAtEpoch(t, 0)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	millis := tick >> fractionBits
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(millis)
+		millis >>= 8
+	}
+
+	fraction := tick & (fractionSteps - 1)
+	u[6] = 0x80 | byte(fraction>>8)
+	u[7] = byte(fraction)
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	if optionFlags(u)&MonotonicFlag != 0 {
+		u[OptionsByteOffset] &^= MonotonicFlag
+	}
+
+	if optionFlags(u)&ChecksumFlag != 0 {
+		u[ChecksumByteOffset] = crc8(u[:ChecksumByteOffset])
+	}
+
+	return u, nil
+}
+
+// FromUUIDv7 converts a UUID version 7 into a country UUID version 8 carrying
+// the same timestamp.
+//
+// The 48-bit millisecond timestamp and the 12 bits following the version,
+// which a v7 generator fills with either random data or a sub-millisecond
+// fraction, are kept as is, so converted UUIDs sort in the same order as the
+// originals apart from ties within the same 1/4096 ms. The country takes the
+// place of 22 of the v7 random bits, and the version is set to 8.
+//
+// GetTimestamp of the result equals the millisecond timestamp of u.
+//
+// Returns an error if u is not version 7 with the RFC 4122 variant, or wrapping
+// ErrInvalidCountryCode if country cannot be stored in the country field.
+func FromUUIDv7(u uuid.UUID, country countries.CountryCode) (uuid.UUID, error) {
+	if version := Version(u); version != 7 {
+		return uuid.Nil, fmt.Errorf("not a UUID v7: version %d", version)
+	}
+
+	if !IsRFC4122Variant(u) {
+		return uuid.Nil, fmt.Errorf("%w: variant %02b", ErrWrongVariant, u[8]>>6)
+	}
+
+	// Clear the flag bits, which hold random data in a v7 UUID
+	u[8] &^= extensionBit | reservedBit
+
+	if err := putCountry(&u, country); err != nil {
+		return uuid.Nil, err
+	}
+	return u, nil
+}
+
+// putCountry stores country in the country field of u and sets version 8 and
+// the RFC 4122 variant, leaving all other bits alone.
+func putCountry(u *uuid.UUID, country countries.CountryCode) error {
+	if !isStorableCountry(country) {
+		return fmt.Errorf("%w: %d", ErrInvalidCountryCode, int64(country))
+	}
+
+	setCountryBits(u, uint32(country))
+
+	// A continent-only UUID becomes a country UUID
+	if optionFlags(*u)&RegionFlag != 0 {
+		u[OptionsByteOffset] &^= RegionFlag
+	}
+
+	// Keep an embedded checksum valid for the new country
+	if optionFlags(*u)&ChecksumFlag != 0 {
+		u[ChecksumByteOffset] = crc8(u[:ChecksumByteOffset])
+	}
+
+	return nil
+}
+
+// setCountryBits stores code in the country field of u and sets version 8 and
+// the RFC 4122 variant. Bits of code beyond the field width are dropped.
+func setCountryBits(u *uuid.UUID, code uint32) {
+	code &= countryMask
+	u[8] = u[8]&^byte(countryMask>>16) | byte(code>>16)
+	u[9] = byte(code >> 8)
+	u[10] = byte(code)
+
+	u[6] = (u[6] & 0x0f) | 0x80
+	u[8] = (u[8] & 0x3f) | 0x80
+}
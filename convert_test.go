@@ -0,0 +1,156 @@
+package uuidv8country
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestWithCountry(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Germany, WithNodeID(9))
+
+	fixed, err := WithCountry(u, countries.Austria)
+	if err != nil {
+		t.Fatalf("WithCountry() error = %v", err)
+	}
+
+	if country := MustExtractCountry(fixed); country != countries.Austria {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Austria)
+	}
+	if !GetTimestampNanos(fixed).Equal(GetTimestampNanos(u)) {
+		t.Errorf("GetTimestampNanos() = %v, expected %v", GetTimestampNanos(fixed), GetTimestampNanos(u))
+	}
+	if node, err := ExtractNodeID(fixed); err != nil || node != 9 {
+		t.Errorf("ExtractNodeID() = %d, %v, expected 9", node, err)
+	}
+
+	// Everything outside the country field is untouched
+	for i := range u {
+		if i >= 8 && i <= 10 {
+			continue
+		}
+		if fixed[i] != u[i] {
+			t.Errorf("byte %d = %#x, expected %#x", i, fixed[i], u[i])
+		}
+	}
+	if fixed[8]&0xf0 != u[8]&0xf0 {
+		t.Errorf("byte 8 high nibble = %#x, expected %#x", fixed[8]&0xf0, u[8]&0xf0)
+	}
+}
+
+func TestWithCountry_FixesVersion(t *testing.T) {
+	fixed, err := WithCountry(uuid.New(), countries.Peru)
+	if err != nil {
+		t.Fatalf("WithCountry() error = %v", err)
+	}
+	if err := Validate(fixed); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestWithCountry_TooLarge(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Germany)
+
+	if _, err := WithCountry(u, countries.CountryCode(1<<CountryBitWidth)); !errors.Is(err, ErrInvalidCountryCode) {
+		t.Errorf("WithCountry() error = %v, expected ErrInvalidCountryCode", err)
+	}
+}
+
+func TestWithTimestamp(t *testing.T) {
+	original, _ := CountryUUIDv8(countries.Egypt, WithNodeID(5), WithChecksum(), WithPayload(77))
+	when := time.Date(2024, 1, 1, 0, 0, 0, 123456789, time.UTC)
+
+	fixed, err := WithTimestamp(original, when)
+	if err != nil {
+		t.Fatalf("WithTimestamp() error = %v", err)
+	}
+
+	if got := GetTimestamp(fixed); !got.Equal(when.Truncate(time.Millisecond)) {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, when.Truncate(time.Millisecond))
+	}
+	if got := GetTimestampNanos(fixed); got.After(when) || when.Sub(got) > time.Microsecond {
+		t.Errorf("GetTimestampNanos() = %v, expected about %v", got, when)
+	}
+	if country := MustExtractCountry(fixed); country != countries.Egypt {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Egypt)
+	}
+	if id, _ := ExtractNodeID(fixed); id != 5 {
+		t.Errorf("ExtractNodeID() = %d, expected 5", id)
+	}
+	if payload := ExtractPayload(fixed); payload != 77 {
+		t.Errorf("ExtractPayload() = %d, expected 77", payload)
+	}
+	if !VerifyChecksum(fixed) {
+		t.Error("VerifyChecksum() = false after WithTimestamp")
+	}
+	if err := Validate(fixed); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	// Only the timestamp bytes change
+	if !bytes.Equal(fixed[8:ChecksumByteOffset], original[8:ChecksumByteOffset]) {
+		t.Errorf("WithTimestamp() changed bytes 8-14: %s, expected %s", fixed, original)
+	}
+}
+
+func TestWithTimestamp_OutOfRange(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Egypt)
+
+	for _, when := range []time.Time{time.UnixMilli(-1), MaxTimestamp.Add(time.Millisecond)} {
+		if _, err := WithTimestamp(u, when); !errors.Is(err, ErrTimestampOutOfRange) {
+			t.Errorf("WithTimestamp(%v) error = %v, expected ErrTimestampOutOfRange", when, err)
+		}
+	}
+}
+
+func TestFromUUIDv7(t *testing.T) {
+	v7s := make([]uuid.UUID, 50)
+	for i := range v7s {
+		v7, err := uuid.NewV7()
+		if err != nil {
+			t.Fatalf("uuid.NewV7() error = %v", err)
+		}
+		v7s[i] = v7
+	}
+
+	for i, v7 := range v7s {
+		u, err := FromUUIDv7(v7, countries.Mexico)
+		if err != nil {
+			t.Fatalf("FromUUIDv7() error = %v", err)
+		}
+
+		if err := Validate(u); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+		if country := MustExtractCountry(u); country != countries.Mexico {
+			t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Mexico)
+		}
+
+		sec, nsec := v7.Time().UnixTime()
+		expected := time.Unix(sec, nsec).Truncate(time.Millisecond)
+		if !GetTimestamp(u).Equal(expected) {
+			t.Errorf("GetTimestamp() = %v, expected %v", GetTimestamp(u), expected)
+		}
+
+		if i > 0 {
+			prev, _ := FromUUIDv7(v7s[i-1], countries.Mexico)
+			if Compare(prev, u) > 0 {
+				t.Errorf("FromUUIDv7() reordered %s and %s", v7s[i-1], v7)
+			}
+		}
+	}
+}
+
+func TestFromUUIDv7_WrongVersion(t *testing.T) {
+	v8, _ := CountryUUIDv8(countries.Mexico)
+
+	for _, u := range []uuid.UUID{uuid.New(), v8} {
+		if _, err := FromUUIDv7(u, countries.Mexico); err == nil {
+			t.Errorf("FromUUIDv7(%s) should return error for version %d", u, u.Version())
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package uuidv8country
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CountryUUID is a uuid.UUID known to be a country UUID v8.
+//
+// It is meant to be embedded in API and storage types: it serializes to the
+// canonical hyphenated string and validates version 8 and the RFC 4122 variant
+// when decoding. It implements encoding.TextMarshaler, so it can be used as a
+// map key in YAML, TOML and JSON documents. It also implements sql.Scanner and
+// driver.Valuer, so it can be stored directly in uuid or text columns.
+//
+// Example:
+//
+//	type Order struct {
+//		ID CountryUUID `json:"id"`
+//	}
+type CountryUUID uuid.UUID
+
+// String implements fmt.Stringer for debug output, returning the canonical
+// form followed by the alpha-2 code of the embedded country in brackets, such
+// as "0190a3b2-8c41-8abc-8348-2f1a9c5d7e60 [DE]". Codes without an alpha-2
+// code, such as countries.Unknown and the non-country codes, show
+// UnknownAlpha2, continent-only UUIDs show their region name and UUIDs that
+// are not version 8 show "invalid".
+//
+// Use Raw().String() or MarshalText for the plain canonical form.
+func (c CountryUUID) String() string {
+	u := uuid.UUID(c)
+
+	var label string
+	if code, err := ExtractCountryAlpha2(u); err == nil {
+		label = code
+	} else if region, err := ExtractRegion(u); err == nil {
+		label = region.String()
+	} else {
+		label = "invalid"
+	}
+
+	return u.String() + " [" + label + "]"
+}
+
+// Raw returns c as a plain uuid.UUID.
+func (c CountryUUID) Raw() uuid.UUID {
+	return uuid.UUID(c)
+}
+
+// MarshalJSON implements json.Marshaler by encoding c as its canonical string form.
+func (c CountryUUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uuid.UUID(c).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// The input must be either a JSON string holding a country UUID v8 as accepted
+// by Parse, or, for clients that send raw bytes, an array of exactly 16 numbers
+// from 0 to 255 holding a UUID v8 with the RFC 4122 variant. A JSON null leaves
+// c unchanged. Any other JSON value is rejected.
+func (c *CountryUUID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '[' {
+		return c.unmarshalJSONArray(data)
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("country UUID must be a JSON string or an array of 16 bytes: %w", err)
+	}
+
+	u, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*c = CountryUUID(u)
+	return nil
+}
+
+// unmarshalJSONArray decodes a UUID sent as a JSON array of byte values.
+func (c *CountryUUID) unmarshalJSONArray(data []byte) error {
+	var values []int
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("country UUID array must hold numbers: %w", err)
+	}
+
+	if len(values) != len(uuid.UUID{}) {
+		return fmt.Errorf("country UUID array must hold 16 bytes, got %d", len(values))
+	}
+
+	var u uuid.UUID
+	for i, v := range values {
+		if v < 0 || v > 0xff {
+			return fmt.Errorf("country UUID array element %d out of byte range: %d", i, v)
+		}
+		u[i] = byte(v)
+	}
+
+	if err := checkVersionVariant(u); err != nil {
+		return err
+	}
+
+	*c = CountryUUID(u)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler by returning the canonical
+// string form of c. This also lets CountryUUID serve as a map key in JSON and
+// other text-based encodings.
+func (c CountryUUID) MarshalText() ([]byte, error) {
+	return []byte(uuid.UUID(c).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// The input must hold a country UUID v8 in one of the forms accepted by Parse.
+func (c *CountryUUID) UnmarshalText(data []byte) error {
+	u, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+
+	*c = CountryUUID(u)
+	return nil
+}
+
+// Value implements driver.Valuer by returning the canonical string form of c.
+func (c CountryUUID) Value() (driver.Value, error) {
+	return uuid.UUID(c).String(), nil
+}
+
+// Scan implements sql.Scanner.
+//
+// It accepts the string forms understood by Parse, either as string or []byte,
+// the 22-character base62 form produced by ToBase62, and the raw 16-byte
+// binary form. Text is parsed as a canonical form first and only then as
+// base62, so a value that could be read both ways is read as canonical. A NULL
+// value leaves c unchanged.
+func (c *CountryUUID) Scan(src interface{}) error {
+	var u uuid.UUID
+
+	switch src := src.(type) {
+	case nil:
+		return nil
+
+	case string:
+		parsed, err := parseScanned(src)
+		if err != nil {
+			return err
+		}
+		u = parsed
+
+	case []byte:
+		if len(src) == 16 {
+			copy(u[:], src)
+			if err := checkVersionVariant(u); err != nil {
+				return err
+			}
+			break
+		}
+
+		parsed, err := parseScanned(string(src))
+		if err != nil {
+			return err
+		}
+		u = parsed
+
+	default:
+		return fmt.Errorf("unable to scan type %T into CountryUUID", src)
+	}
+
+	*c = CountryUUID(u)
+	return nil
+}
+
+// parseScanned parses s with Parse, falling back to FromBase62 for strings of
+// the base62 length.
+func parseScanned(s string) (uuid.UUID, error) {
+	u, err := Parse(s)
+	if err == nil || len(s) != Base62Length {
+		return u, err
+	}
+
+	return FromBase62(s)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler by returning the 16 raw bytes of c.
+func (c CountryUUID) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), c[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+//
+// The input must be exactly 16 bytes holding a UUID with version 8 and the
+// RFC 4122 variant.
+func (c *CountryUUID) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("invalid country UUID length: %d bytes, expected 16", len(data))
+	}
+
+	var u uuid.UUID
+	copy(u[:], data)
+	if err := checkVersionVariant(u); err != nil {
+		return err
+	}
+
+	*c = CountryUUID(u)
+	return nil
+}
+
+// CountryUUIDArray is a slice of country UUIDs that reads and writes
+// PostgreSQL uuid[] columns in their text form, {uuid,uuid,...}, without
+// depending on a driver-specific array type:
+//
+//	var ids CountryUUIDArray
+//	err := db.QueryRow("SELECT ids FROM batches WHERE id = $1", id).Scan(&ids)
+type CountryUUIDArray []CountryUUID
+
+// Value implements driver.Valuer by returning the array literal of a, such as
+// {018d1234-5678-8abc-bdef-0123456789ab}. A nil slice is stored as NULL and an
+// empty one as {}.
+func (a CountryUUIDArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	buf := make([]byte, 0, 2+len(a)*37)
+	buf = append(buf, '{')
+	for i, c := range a {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, uuid.UUID(c).String()...)
+	}
+	buf = append(buf, '}')
+
+	return string(buf), nil
+}
+
+// Scan implements sql.Scanner for one-dimensional array literals, given as
+// string or []byte. Elements may be double-quoted and must each be a country
+// UUID v8 in one of the forms accepted by Parse; all of them are validated
+// before a is modified. A NULL array sets a to nil, while NULL elements are
+// rejected, since they have no CountryUUID equivalent.
+//
+// Returns an error for malformed literals, naming the index of the offending
+// element and wrapping the Parse error, such as ErrWrongVersion.
+func (a *CountryUUIDArray) Scan(src interface{}) error {
+	var literal string
+
+	switch src := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		literal = src
+	case []byte:
+		literal = string(src)
+	default:
+		return fmt.Errorf("unable to scan type %T into CountryUUIDArray", src)
+	}
+
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return fmt.Errorf("invalid array literal %q: expected {uuid,...}", literal)
+	}
+
+	inner := literal[1 : len(literal)-1]
+	if strings.ContainsAny(inner, "{}") {
+		return fmt.Errorf("invalid array literal %q: only one-dimensional arrays are supported", literal)
+	}
+	if inner == "" {
+		*a = CountryUUIDArray{}
+		return nil
+	}
+
+	elements := strings.Split(inner, ",")
+	result := make(CountryUUIDArray, len(elements))
+	for i, element := range elements {
+		element = strings.TrimSpace(element)
+		if len(element) >= 2 && element[0] == '"' && element[len(element)-1] == '"' {
+			element = element[1 : len(element)-1]
+		} else if strings.EqualFold(element, "NULL") {
+			return fmt.Errorf("array element %d is NULL", i)
+		}
+
+		u, err := Parse(element)
+		if err != nil {
+			return fmt.Errorf("array element %d: %w", i, err)
+		}
+		result[i] = CountryUUID(u)
+	}
+
+	*a = result
+	return nil
+}
@@ -0,0 +1,439 @@
+package uuidv8country
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestCountryUUID_JSONRoundTrip(t *testing.T) {
+	type payload struct {
+		ID CountryUUID `json:"id"`
+	}
+
+	u, err := CountryUUIDv8(countries.Italy)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+
+	data, err := json.Marshal(payload{ID: CountryUUID(u)})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"id":"` + u.String() + `"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, expected %s", data, want)
+	}
+
+	var decoded payload
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if uuid.UUID(decoded.ID) != u {
+		t.Errorf("json.Unmarshal() = %s, expected %s", uuid.UUID(decoded.ID), u)
+	}
+}
+
+func TestCountryUUID_UnmarshalJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Version4", `"` + uuid.New().String() + `"`},
+		{"Malformed", `"not-a-uuid"`},
+		{"Number", `42`},
+		{"Object", `{}`},
+		{"Bool", `true`},
+		{"ShortArray", `[1, 2, 3]`},
+		{"ArrayOfStrings", `["a", "b"]`},
+		{"ArrayOutOfRange", `[256, 0, 0, 0, 0, 0, 128, 0, 128, 0, 0, 0, 0, 0, 0, 0]`},
+		{"ArrayVersion4", arrayJSON(uuid.New())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c CountryUUID
+			if err := json.Unmarshal([]byte(tt.input), &c); err == nil {
+				t.Errorf("json.Unmarshal(%s) should return error", tt.input)
+			}
+		})
+	}
+}
+
+// arrayJSON encodes u as a JSON array of its 16 byte values.
+func arrayJSON(u uuid.UUID) string {
+	values := make([]int, len(u))
+	for i, b := range u {
+		values[i] = int(b)
+	}
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+func TestCountryUUID_UnmarshalJSONArray(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Greece)
+
+	var c CountryUUID
+	if err := json.Unmarshal([]byte(arrayJSON(u)), &c); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if uuid.UUID(c) != u {
+		t.Errorf("json.Unmarshal() = %s, expected %s", uuid.UUID(c), u)
+	}
+
+	// Nested in a struct, next to the string form
+	var ids struct {
+		A CountryUUID `json:"a"`
+		B CountryUUID `json:"b"`
+	}
+	input := `{"a": "` + u.String() + `", "b": ` + arrayJSON(u) + `}`
+	if err := json.Unmarshal([]byte(input), &ids); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if ids.A != ids.B {
+		t.Errorf("json.Unmarshal() string form = %s, array form = %s", uuid.UUID(ids.A), uuid.UUID(ids.B))
+	}
+}
+
+func TestCountryUUID_UnmarshalJSONNull(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Italy)
+	c := CountryUUID(u)
+
+	if err := json.Unmarshal([]byte(`null`), &c); err != nil {
+		t.Fatalf("json.Unmarshal(null) error = %v", err)
+	}
+	if uuid.UUID(c) != u {
+		t.Errorf("json.Unmarshal(null) modified value: %s", uuid.UUID(c))
+	}
+}
+
+func TestCountryUUID_TextRoundTrip(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Norway)
+
+	text, err := CountryUUID(u).MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() error = %v", err)
+	}
+	if string(text) != u.String() {
+		t.Errorf("MarshalText() = %s, expected %s", text, u.String())
+	}
+
+	var c CountryUUID
+	if err := c.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText() error = %v", err)
+	}
+	if uuid.UUID(c) != u {
+		t.Errorf("UnmarshalText() = %s, expected %s", uuid.UUID(c), u)
+	}
+
+	if err := c.UnmarshalText([]byte(uuid.New().String())); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("UnmarshalText() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestCountryUUID_MapKey(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Norway)
+	m := map[CountryUUID]int{CountryUUID(u): 1}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	expected := `{"` + u.String() + `":1}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal() = %s, expected %s", data, expected)
+	}
+
+	var decoded map[CountryUUID]int
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded[CountryUUID(u)] != 1 {
+		t.Errorf("json.Unmarshal() = %v, expected key %s", decoded, u)
+	}
+}
+
+func TestCountryUUID_Value(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Spain)
+
+	v, err := CountryUUID(u).Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	if v != u.String() {
+		t.Errorf("Value() = %v, expected %s", v, u)
+	}
+}
+
+func TestCountryUUID_Scan(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Spain)
+
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{"String", u.String()},
+		{"Bytes", []byte(u.String())},
+		{"Binary", u[:]},
+		{"Base62String", ToBase62(u)},
+		{"Base62Bytes", []byte(ToBase62(u))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c CountryUUID
+			if err := c.Scan(tt.src); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if uuid.UUID(c) != u {
+				t.Errorf("Scan() = %s, expected %s", uuid.UUID(c), u)
+			}
+		})
+	}
+}
+
+func TestCountryUUID_ScanInvalid(t *testing.T) {
+	v4 := uuid.New()
+
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{"Version4String", v4.String()},
+		{"Version4Binary", v4[:]},
+		{"Version4Base62", ToBase62(v4)},
+		{"Malformed", "not-a-uuid"},
+		{"MalformedBase62Length", "not-a-uuid-not-a-uuid!"},
+		{"UnsupportedType", 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c CountryUUID
+			if err := c.Scan(tt.src); err == nil {
+				t.Errorf("Scan(%v) should return error", tt.src)
+			}
+		})
+	}
+}
+
+func TestCountryUUID_ScanNull(t *testing.T) {
+	var c CountryUUID
+	if err := c.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error = %v", err)
+	}
+	if uuid.UUID(c) != uuid.Nil {
+		t.Errorf("Scan(nil) = %s, expected zero value", uuid.UUID(c))
+	}
+}
+
+func TestCountryUUID_BinaryRoundTrip(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Turkey)
+
+	data, err := CountryUUID(u).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	if !bytes.Equal(data, u[:]) {
+		t.Errorf("MarshalBinary() = %x, expected %x", data, u[:])
+	}
+
+	var c CountryUUID
+	if err := c.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if uuid.UUID(c) != u {
+		t.Errorf("UnmarshalBinary() = %s, expected %s", uuid.UUID(c), u)
+	}
+}
+
+func TestCountryUUID_Gob(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Turkey)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(CountryUUID(u)); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	var c CountryUUID
+	if err := gob.NewDecoder(&buf).Decode(&c); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if uuid.UUID(c) != u {
+		t.Errorf("Decode() = %s, expected %s", uuid.UUID(c), u)
+	}
+}
+
+func TestCountryUUID_UnmarshalBinaryInvalid(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Turkey)
+	v4 := uuid.New()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"Short", u[:15]},
+		{"Long", append(u[:], 0)},
+		{"Version4", v4[:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c CountryUUID
+			if err := c.UnmarshalBinary(tt.data); err == nil {
+				t.Errorf("UnmarshalBinary(%x) should return error", tt.data)
+			}
+		})
+	}
+}
+
+func TestCountryUUID_String(t *testing.T) {
+	country, _ := CountryUUIDv8(countries.Germany)
+	continent, _ := ContinentUUIDv8(countries.RegionEU)
+	unknown, _ := CountryUUIDv8(countries.Unknown)
+	inmarsat, _ := CountryUUIDv8(countries.NonCountryInmarsat)
+	international, _ := CountryUUIDv8(countries.International)
+	foreign := uuid.New()
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want string
+	}{
+		{"Country", country, country.String() + " [DE]"},
+		{"Unknown", unknown, unknown.String() + " [XX]"},
+		{"NonCountry", inmarsat, inmarsat.String() + " [XX]"},
+		{"International", international, international.String() + " [XX]"},
+		{"ContinentOnly", continent, continent.String() + " [Europe]"},
+		{"NotV8", foreign, foreign.String() + " [invalid]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := CountryUUID(tt.u)
+			if got := c.String(); got != tt.want {
+				t.Errorf("String() = %q, expected %q", got, tt.want)
+			}
+			if got := fmt.Sprintf("%v", c); got != tt.want {
+				t.Errorf("Sprintf(%%v) = %q, expected %q", got, tt.want)
+			}
+			if got := c.Raw(); got != tt.u {
+				t.Errorf("Raw() = %s, expected %s", got, tt.u)
+			}
+		})
+	}
+}
+
+func TestCountryUUIDArray_Scan(t *testing.T) {
+	a, _ := CountryUUIDv8(countries.Spain)
+	b, _ := CountryUUIDv8(countries.Chile)
+
+	tests := []struct {
+		name string
+		src  interface{}
+		want CountryUUIDArray
+	}{
+		{"String", "{" + a.String() + "," + b.String() + "}", CountryUUIDArray{CountryUUID(a), CountryUUID(b)}},
+		{"Bytes", []byte("{" + a.String() + "}"), CountryUUIDArray{CountryUUID(a)}},
+		{"Quoted", `{"` + a.String() + `", "` + b.String() + `"}`, CountryUUIDArray{CountryUUID(a), CountryUUID(b)}},
+		{"Empty", "{}", CountryUUIDArray{}},
+		{"Null", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CountryUUIDArray{CountryUUID(b)}
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || len(got) != len(tt.want) {
+				t.Fatalf("Scan() = %v, expected %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Scan()[%d] = %s, expected %s", i, uuid.UUID(got[i]), uuid.UUID(tt.want[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestCountryUUIDArray_ScanInvalid(t *testing.T) {
+	a, _ := CountryUUIDv8(countries.Spain)
+	v4 := uuid.New()
+
+	tests := []struct {
+		name string
+		src  interface{}
+		want error
+	}{
+		{"Version4Element", "{" + a.String() + "," + v4.String() + "}", ErrWrongVersion},
+		{"NullElement", "{" + a.String() + ",NULL}", nil},
+		{"MalformedElement", "{not-a-uuid}", nil},
+		{"EmptyElement", "{" + a.String() + ",}", nil},
+		{"NoBraces", a.String(), nil},
+		{"MultiDimensional", "{{" + a.String() + "},{" + a.String() + "}}", nil},
+		{"UnsupportedType", 42, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arr := CountryUUIDArray{CountryUUID(a)}
+			err := arr.Scan(tt.src)
+			if err == nil {
+				t.Fatalf("Scan(%v) should return error", tt.src)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Scan() error = %v, expected %v", err, tt.want)
+			}
+			if len(arr) != 1 || arr[0] != CountryUUID(a) {
+				t.Errorf("Scan() modified the array on error: %v", arr)
+			}
+		})
+	}
+}
+
+func TestCountryUUIDArray_Value(t *testing.T) {
+	a, _ := CountryUUIDv8(countries.Spain)
+	b, _ := CountryUUIDv8(countries.Chile)
+
+	tests := []struct {
+		name string
+		arr  CountryUUIDArray
+		want driver.Value
+	}{
+		{"Two", CountryUUIDArray{CountryUUID(a), CountryUUID(b)}, "{" + a.String() + "," + b.String() + "}"},
+		{"Empty", CountryUUIDArray{}, "{}"},
+		{"Nil", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arr.Value()
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Value() = %v, expected %v", got, tt.want)
+			}
+
+			// Round trip through Scan
+			var back CountryUUIDArray
+			if err := back.Scan(got); err != nil {
+				t.Fatalf("Scan(Value()) error = %v", err)
+			}
+			if len(back) != len(tt.arr) || (back == nil) != (tt.arr == nil) {
+				t.Errorf("Scan(Value()) = %v, expected %v", back, tt.arr)
+			}
+		})
+	}
+}
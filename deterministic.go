@@ -0,0 +1,93 @@
+package uuidv8country
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// CountryUUIDv8Deterministic derives a UUID version 8 for country from key,
+// for idempotent ids: the same country and key always yield the same UUID, so
+// a retried request does not create a duplicate.
+//
+// All bits other than version, variant and country are taken from the SHA-256
+// hash of country and key instead of random data and the clock, with the extension and
+// reserved flag bits cleared. Different keys give different UUIDs with the
+// collision odds of a 100-bit hash.
+//
+// It panics with an error wrapping ErrInvalidCountryCode if country cannot be
+// stored in the country field, like the codes CountryUUIDv8 rejects: negative
+// codes, codes wider than 20 bits and codes in the range reserved for regions.
+// Such a UUID would decode to a different country than the one passed in.
+//
+// The timestamp fields hold hash bits too, so GetTimestamp and Compare are not
+// meaningful for these UUIDs. ExtractCountry and Validate work as usual.
+//
+// Example:
+//
+//	u := CountryUUIDv8Deterministic(countries.Sweden, []byte("order-1234"))
+//	fmt.Println(u == CountryUUIDv8Deterministic(countries.Sweden, []byte("order-1234"))) // Output: true
+func CountryUUIDv8Deterministic(country countries.CountryCode, key []byte) uuid.UUID {
+	mustStoreCountry("CountryUUIDv8Deterministic", country)
+
+	// Hash the country along with the key, so each country is its own
+	// namespace and the same key gives unrelated UUIDs for different countries
+	h := sha256.New()
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(country)))
+	h.Write(key)
+
+	var u uuid.UUID
+	copy(u[:], h.Sum(nil))
+
+	u[8] &^= extensionBit | reservedBit
+
+	setCountryBits(&u, uint32(country))
+
+	return u
+}
+
+// FixedUUID returns the UUID version 8 that stands for country in snapshot
+// tests and golden files: it depends on nothing but the country, so it stays
+// the same across runs, machines and releases without touching the clock or
+// the random source.
+//
+// The timestamp is zero, 1970-01-01 UTC, and the random bits are taken from
+// the SHA-256 hash of the country code, with the extension and reserved flag
+// bits cleared. Such UUIDs carry no meaningful creation time, so they must not
+// be used where GetTimestamp, Compare or Age matter, and every call for the
+// same country returns the same UUID, so they are not unique ids either.
+// ExtractCountry and Validate work as usual. Like CountryUUIDv8Deterministic it
+// panics with an error wrapping ErrInvalidCountryCode for codes that cannot
+// be stored in the country field.
+//
+// Example:
+//
+//	want := FixedUUID(countries.Sweden)
+//	fmt.Println(want == FixedUUID(countries.Sweden)) // Output: true
+func FixedUUID(country countries.CountryCode) uuid.UUID {
+	mustStoreCountry("FixedUUID", country)
+
+	// Prefix the code, so the hash differs from that of a
+	// CountryUUIDv8Deterministic key
+	sum := sha256.Sum256(binary.BigEndian.AppendUint32([]byte("FixedUUID"), uint32(country)))
+
+	var u uuid.UUID
+	copy(u[8:], sum[:8])
+
+	u[8] &^= extensionBit | reservedBit
+
+	setCountryBits(&u, uint32(country))
+
+	return u
+}
+
+// mustStoreCountry panics if country cannot be stored in the country field,
+// for the derivation functions that have no error to return.
+func mustStoreCountry(fn string, country countries.CountryCode) {
+	if !isStorableCountry(country) {
+		panic(fmt.Errorf("uuidv8country: %s: %w: %d", fn, ErrInvalidCountryCode, int64(country)))
+	}
+}
@@ -0,0 +1,121 @@
+package uuidv8country
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestCountryUUIDv8Deterministic(t *testing.T) {
+	a := CountryUUIDv8Deterministic(countries.Sweden, []byte("order-1234"))
+	b := CountryUUIDv8Deterministic(countries.Sweden, []byte("order-1234"))
+	if a != b {
+		t.Errorf("CountryUUIDv8Deterministic() = %s and %s for identical inputs", a, b)
+	}
+
+	if err := Validate(a); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if country := MustExtractCountry(a); country != countries.Sweden {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Sweden)
+	}
+	if flags := optionFlags(a); flags != 0 {
+		t.Errorf("option flags = %08b, expected none", flags)
+	}
+	if !HasKnownCountry(a) {
+		t.Error("HasKnownCountry() = false, expected true")
+	}
+}
+
+func TestCountryUUIDv8Deterministic_Differs(t *testing.T) {
+	seen := make(map[uuid.UUID]string)
+
+	for _, key := range []string{"", "a", "b", "order-1234", "order-1235"} {
+		for _, country := range []countries.CountryCode{countries.Sweden, countries.Norway} {
+			u := CountryUUIDv8Deterministic(country, []byte(key))
+			if prev, ok := seen[u]; ok {
+				t.Errorf("CountryUUIDv8Deterministic(%v, %q) = %s, same as for %s", country, key, u, prev)
+			}
+			seen[u] = country.String() + "/" + key
+		}
+	}
+
+	// Not just the country bits differ between countries
+	se := CountryUUIDv8Deterministic(countries.Sweden, []byte("k"))
+	no := CountryUUIDv8Deterministic(countries.Norway, []byte("k"))
+	if GetTimestamp(se).Equal(GetTimestamp(no)) {
+		t.Error("CountryUUIDv8Deterministic() shares hash bits across countries")
+	}
+}
+
+func TestFixedUUID(t *testing.T) {
+	// Golden values: changing them breaks every snapshot built on FixedUUID
+	golden := map[countries.CountryCode]string{
+		countries.Sweden:  "00000000-0000-8000-8002-f09e0b932825",
+		countries.Unknown: "00000000-0000-8000-8000-009dd6e6f655",
+	}
+	for country, want := range golden {
+		if got := FixedUUID(country).String(); got != want {
+			t.Errorf("FixedUUID(%v) = %s, expected %s", country, got, want)
+		}
+	}
+
+	seen := make(map[uuid.UUID]countries.CountryCode)
+	for _, country := range countries.All() {
+		u := FixedUUID(country)
+		if u != FixedUUID(country) {
+			t.Fatalf("FixedUUID(%v) is not stable", country)
+		}
+		if prev, ok := seen[u]; ok {
+			t.Errorf("FixedUUID(%v) = %s, same as for %v", country, u, prev)
+		}
+		seen[u] = country
+
+		if got := MustExtractCountry(u); got != country {
+			t.Errorf("ExtractCountry(FixedUUID(%v)) = %v", country, got)
+		}
+		if ts := GetTimestampNanos(u); ts.UnixNano() != 0 {
+			t.Errorf("GetTimestampNanos(FixedUUID(%v)) = %v, expected the UNIX epoch", country, ts)
+		}
+		if flags := optionFlags(u); flags != 0 {
+			t.Errorf("option flags = %08b, expected none", flags)
+		}
+	}
+
+	if FixedUUID(countries.Sweden) == CountryUUIDv8Deterministic(countries.Sweden, nil) {
+		t.Error("FixedUUID() equals CountryUUIDv8Deterministic() with an empty key")
+	}
+}
+
+func TestDeterministic_InvalidCountry(t *testing.T) {
+	derive := map[string]func(countries.CountryCode){
+		"CountryUUIDv8Deterministic": func(c countries.CountryCode) { CountryUUIDv8Deterministic(c, []byte("k")) },
+		"FixedUUID":                  func(c countries.CountryCode) { FixedUUID(c) },
+	}
+
+	for name, fn := range derive {
+		for _, country := range []countries.CountryCode{-1, RegionCodeBase, 1 << CountryBitWidth, 1<<CountryBitWidth + countries.Sweden} {
+			func() {
+				defer func() {
+					err, _ := recover().(error)
+					if !errors.Is(err, ErrInvalidCountryCode) {
+						t.Errorf("%s(%d) panicked with %v, expected ErrInvalidCountryCode", name, country, err)
+					}
+				}()
+				fn(country)
+			}()
+		}
+
+		// The largest storable code still works
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s(%d) panicked: %v", name, RegionCodeBase-1, r)
+				}
+			}()
+			fn(RegionCodeBase - 1)
+		}()
+	}
+}
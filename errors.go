@@ -0,0 +1,66 @@
+package uuidv8country
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrWrongVersion is returned when a UUID is not version 8.
+	ErrWrongVersion = errors.New("not a UUID v8")
+
+	// ErrWrongVariant is returned when a UUID does not carry the RFC 4122 variant.
+	ErrWrongVariant = errors.New("not an RFC 4122 UUID")
+
+	// ErrUnknownCountry is returned when the embedded country code does not
+	// decode to a country known to the countries package.
+	ErrUnknownCountry = errors.New("unknown country")
+
+	// ErrInvalidCountryCode is returned when generating a UUID for a country
+	// code that cannot be stored in the country field: negative codes, codes
+	// wider than 20 bits and codes in the range reserved for regions. It wraps
+	// ErrUnknownCountry, so errors.Is matches both.
+	ErrInvalidCountryCode = fmt.Errorf("%w: invalid country code", ErrUnknownCountry)
+
+	// ErrZeroUUID is returned by Validate for the zero UUID, which usually means
+	// an id that was never set rather than a corrupted one. It wraps
+	// ErrWrongVersion, so errors.Is matches both.
+	ErrZeroUUID = fmt.Errorf("%w: zero UUID", ErrWrongVersion)
+
+	// ErrTimestampOutOfRange is returned when generating a UUID for a time
+	// before the generator's epoch or after the last millisecond the 48-bit
+	// timestamp field can hold, MaxTimestamp for the default epoch, or when a
+	// monotonic counter would run past that millisecond.
+	ErrTimestampOutOfRange = errors.New("timestamp out of range")
+
+	// ErrReservedBitsSet is returned by ValidateStrict for UUIDs with the
+	// reserved bit or one of the ReservedOptionFlags set.
+	ErrReservedBitsSet = errors.New("reserved bits set")
+
+	// ErrNoEntropySource is returned when generating with a Generator created
+	// WithRand(nil).
+	ErrNoEntropySource = errors.New("no entropy source")
+
+	// ErrInsufficientEntropy is returned when a generator's random source runs
+	// out or fails before supplying all the random bytes a UUID needs, as a
+	// fixed-size reader in a test does once exhausted. The error also wraps
+	// the source's own error, such as io.EOF.
+	ErrInsufficientEntropy = errors.New("insufficient entropy")
+
+	// ErrNoNodeID is returned by ExtractNodeID for UUIDs generated without WithNodeID.
+	ErrNoNodeID = errors.New("no node id embedded")
+
+	// ErrContinentOnly is returned when extracting the country of a
+	// continent-only UUID generated by ContinentUUIDv8.
+	ErrContinentOnly = errors.New("continent-only UUID has no country")
+)
+
+// versionErrors holds the ErrWrongVersion error for every possible version
+// nibble, so the decode functions can report it without allocating.
+var versionErrors [16]error
+
+func init() {
+	for version := range versionErrors {
+		versionErrors[version] = fmt.Errorf("%w: version %d", ErrWrongVersion, version)
+	}
+}
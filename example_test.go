@@ -0,0 +1,67 @@
+package uuidv8country_test
+
+import (
+	"fmt"
+	mrand "math/rand"
+	"time"
+
+	"github.com/biter777/countries"
+	uuidv8country "github.com/jombG/uuid-v8-country"
+)
+
+// fixedClock is a Clock that always reads the same instant, so the examples
+// produce stable output.
+type fixedClock struct{ t time.Time }
+
+func (c fixedClock) Now() time.Time { return c.t }
+
+// exampleGenerator returns a generator with seeded randomness and a fixed
+// clock. Real code uses the package-level functions or NewGenerator without
+// options instead.
+func exampleGenerator() *uuidv8country.Generator {
+	return uuidv8country.NewGenerator(
+		uuidv8country.WithRand(mrand.New(mrand.NewSource(1))),
+		uuidv8country.WithClock(fixedClock{time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}),
+	)
+}
+
+func ExampleCountryUUIDv8() {
+	u, err := uuidv8country.CountryUUIDv8(countries.Germany)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(uuidv8country.Version(u))
+	fmt.Println(uuidv8country.MustExtractCountry(u))
+
+	// A seeded generator with a fixed clock yields the same UUID every time
+	g := exampleGenerator()
+	fixed, _ := g.CountryUUIDv8(countries.Germany)
+	fmt.Println(fixed)
+	// Output:
+	// 8
+	// Germany
+	// 01972b5c-ee00-8000-8001-140f9a621d72
+}
+
+func ExampleExtractCountry() {
+	u := uuidv8country.MustParse("01972b5c-ee00-8000-8001-140f9a621d72")
+
+	country, err := uuidv8country.ExtractCountry(u)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(country, country.Alpha2())
+	// Output:
+	// Germany DE
+}
+
+func ExampleGetTimestamp() {
+	g := exampleGenerator()
+	u, _ := g.CountryUUIDv8(countries.Japan)
+
+	fmt.Println(uuidv8country.GetTimestamp(u))
+	// Output:
+	// 2025-06-01 12:00:00 +0000 UTC
+}
@@ -0,0 +1,101 @@
+package uuidv8country
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FormatStyle selects one of the string forms produced by Format.
+type FormatStyle int
+
+const (
+	// Canonical is the lowercase hyphenated form returned by uuid.UUID.String,
+	// such as "0190a3b2-8c41-8abc-8348-2f1a9c5d7e60".
+	Canonical FormatStyle = iota
+
+	// Upper is the canonical form in uppercase.
+	Upper
+
+	// Braced is the canonical form enclosed in curly braces, as used by
+	// Microsoft tooling.
+	Braced
+
+	// URN is the canonical form prefixed with "urn:uuid:" (RFC 4122 section 3).
+	URN
+)
+
+// String returns the name of the style.
+func (s FormatStyle) String() string {
+	switch s {
+	case Canonical:
+		return "canonical"
+	case Upper:
+		return "upper"
+	case Braced:
+		return "braced"
+	case URN:
+		return "urn"
+	}
+	return "FormatStyle(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Format returns u in the given style. Unknown styles fall back to Canonical.
+//
+// Parse accepts every style, so the result of Format always parses back to u.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Japan)
+//	fmt.Println(Format(u, URN)) // Output: urn:uuid:xxxxxxxx-xxxx-8xxx-xxxx-xxxxxxxxxxxx
+func Format(u uuid.UUID, style FormatStyle) string {
+	switch style {
+	case Upper:
+		return strings.ToUpper(u.String())
+	case Braced:
+		return "{" + u.String() + "}"
+	case URN:
+		return u.URN()
+	}
+	return u.String()
+}
+
+// AppendCanonical appends the canonical hyphenated form of u to dst and returns
+// the extended buffer, like strconv.AppendInt. It does not allocate when dst
+// has room for 36 more bytes.
+func AppendCanonical(dst []byte, u uuid.UUID) []byte {
+	n := len(dst)
+	dst = append(dst, "00000000-0000-0000-0000-000000000000"...)
+	buf := dst[n:]
+
+	hex.Encode(buf[0:8], u[0:4])
+	hex.Encode(buf[9:13], u[4:6])
+	hex.Encode(buf[14:18], u[6:8])
+	hex.Encode(buf[19:23], u[8:10])
+	hex.Encode(buf[24:36], u[10:16])
+
+	return dst
+}
+
+// AppendWithCountry appends u followed by the name of its embedded country in
+// parentheses, such as "0190a3b2-8c41-8abc-8348-2f1a9c5d7e60 (Germany)", for
+// log lines. Continent-only UUIDs show their region, and UUIDs that are not
+// version 8 show "invalid" instead of a country.
+//
+// Like AppendCanonical it does not allocate when dst has enough room.
+func AppendWithCountry(dst []byte, u uuid.UUID) []byte {
+	dst = AppendCanonical(dst, u)
+	dst = append(dst, " ("...)
+
+	if country, err := ExtractCountry(u); err == nil {
+		dst = append(dst, country.String()...)
+	} else if region, err := ExtractRegion(u); err == nil {
+		dst = append(dst, region.String()...)
+	} else {
+		dst = append(dst, "invalid"...)
+	}
+
+	return append(dst, ')')
+}
@@ -0,0 +1,107 @@
+package uuidv8country
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestFormat(t *testing.T) {
+	u := uuid.MustParse("0190a3b2-8c41-8abc-8348-2f1a9c5d7e60")
+
+	tests := []struct {
+		style FormatStyle
+		want  string
+	}{
+		{Canonical, "0190a3b2-8c41-8abc-8348-2f1a9c5d7e60"},
+		{Upper, "0190A3B2-8C41-8ABC-8348-2F1A9C5D7E60"},
+		{Braced, "{0190a3b2-8c41-8abc-8348-2f1a9c5d7e60}"},
+		{URN, "urn:uuid:0190a3b2-8c41-8abc-8348-2f1a9c5d7e60"},
+		{FormatStyle(42), "0190a3b2-8c41-8abc-8348-2f1a9c5d7e60"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style.String(), func(t *testing.T) {
+			if got := Format(u, tt.style); got != tt.want {
+				t.Errorf("Format() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_ParseRoundTrip(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Croatia)
+
+	for _, style := range []FormatStyle{Canonical, Upper, Braced, URN} {
+		s := Format(u, style)
+
+		parsed, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", s, err)
+		}
+		if parsed != u {
+			t.Errorf("Parse(%q) = %s, expected %s", s, parsed, u)
+		}
+
+		// Still rejects non-v8 UUIDs in every style
+		if _, err := Parse(Format(uuid.New(), style)); !errors.Is(err, ErrWrongVersion) {
+			t.Errorf("Parse() error = %v, expected ErrWrongVersion for a v4 UUID in %v style", err, style)
+		}
+	}
+
+	if _, err := Parse(strings.ToUpper(Format(u, URN))); err != nil {
+		t.Errorf("Parse() error = %v for an uppercase URN", err)
+	}
+}
+
+func TestAppendCanonical(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Croatia)
+
+	got := AppendCanonical([]byte("id="), u)
+	if expected := "id=" + u.String(); string(got) != expected {
+		t.Errorf("AppendCanonical() = %q, expected %q", got, expected)
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendCanonical(buf[:0], u)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendCanonical() allocs = %v, expected 0", allocs)
+	}
+}
+
+func TestAppendWithCountry(t *testing.T) {
+	germany, _ := CountryUUIDv8(countries.Germany)
+	europe, _ := ContinentUUIDv8(countries.RegionEU)
+	v4 := uuid.New()
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want string
+	}{
+		{"Country", germany, germany.String() + " (Germany)"},
+		{"Continent", europe, europe.String() + " (Europe)"},
+		{"Invalid", v4, v4.String() + " (invalid)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AppendWithCountry(nil, tt.u); string(got) != tt.want {
+				t.Errorf("AppendWithCountry() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendWithCountry(buf[:0], germany)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendWithCountry() allocs = %v, expected 0", allocs)
+	}
+}
@@ -0,0 +1,61 @@
+package uuidv8country
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/biter777/countries"
+)
+
+func FuzzCountryRoundTrip(f *testing.F) {
+	for _, c := range []int64{0, 4, 276, 840, 900, 998, 999, 999991, RegionCodeBase - 1, RegionCodeBase, -1} {
+		f.Add(c)
+	}
+
+	f.Fuzz(func(t *testing.T, code int64) {
+		country := countries.CountryCode(code)
+
+		u, err := CountryUUIDv8(country)
+		if !isStorableCountry(country) {
+			if !errors.Is(err, ErrInvalidCountryCode) {
+				t.Fatalf("CountryUUIDv8(%d) error = %v, expected ErrInvalidCountryCode", code, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("CountryUUIDv8(%d) error = %v", code, err)
+		}
+
+		got, err := ExtractCountry(u)
+		if err != nil {
+			t.Fatalf("ExtractCountry() error = %v", err)
+		}
+		if got != country {
+			t.Errorf("ExtractCountry() = %d, expected %d", int64(got), code)
+		}
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	u, _ := CountryUUIDv8(countries.Norway)
+	for _, style := range []FormatStyle{Canonical, Upper, Braced, URN} {
+		f.Add(Format(u, style))
+	}
+	f.Add("")
+	f.Add("00000000-0000-0000-0000-000000000000")
+	f.Add("not a uuid")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		u, err := Parse(s)
+		if err != nil {
+			return
+		}
+
+		if err := checkVersionVariant(u); err != nil {
+			t.Errorf("Parse(%q) = %s, which fails with %v", s, u, err)
+		}
+		if _, err := ExtractRawCode(u); err != nil {
+			t.Errorf("ExtractRawCode() error = %v", err)
+		}
+	})
+}
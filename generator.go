@@ -0,0 +1,848 @@
+package uuidv8country
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+const (
+	// fractionBits is the number of bits following the version nibble that
+	// hold the sub-millisecond fraction of the timestamp.
+	fractionBits = FractionBitWidth
+
+	// fractionSteps is the number of sub-millisecond steps, giving an
+	// effective resolution of 1ms/4096 (about 244ns).
+	fractionSteps = 1 << fractionBits
+
+	// maxMillis is the largest millisecond offset from the epoch that fits the
+	// timestamp field.
+	maxMillis = 1<<TimestampBitWidth - 1
+
+	// maxTick is the last value of the 60-bit timestamp fields, the final
+	// fraction of maxMillis.
+	maxTick = maxMillis<<fractionBits | (fractionSteps - 1)
+)
+
+// MaxTimestamp is the last millisecond a UUID generated with the default UNIX
+// epoch can carry, 10889-08-02 05:31:50.655 UTC. Times after its final 1/4096
+// ms fail with ErrTimestampOutOfRange instead of wrapping around. A generator
+// created WithEpoch shifts the limit by its epoch.
+var MaxTimestamp = time.UnixMilli(maxMillis).UTC()
+
+// TimestampSource reports what determined the timestamp embedded in a UUID.
+type TimestampSource int
+
+const (
+	// FromClock means the timestamp was taken from the generator's clock.
+	FromClock TimestampSource = iota
+
+	// FromCounter means the clock had not advanced past the previous UUID
+	// (or had stepped backwards), so a monotonic generator reused its last
+	// timestamp and advanced the counter instead.
+	FromCounter
+)
+
+// String returns "clock" or "counter".
+func (s TimestampSource) String() string {
+	if s == FromCounter {
+		return "counter"
+	}
+	return "clock"
+}
+
+// Clock supplies the current time to a Generator.
+//
+// Tests can inject a fake Clock with WithClock to control the embedded
+// timestamps exactly.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock, backed by time.Now.
+type systemClock struct{}
+
+// Now returns the current local time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// GeneratorOption configures a Generator created by NewGenerator.
+type GeneratorOption func(*Generator)
+
+// WithRand makes the generator read random bits from r instead of
+// crypto/rand. With a nil r, generation fails with ErrNoEntropySource.
+func WithRand(r io.Reader) GeneratorOption {
+	return func(g *Generator) {
+		g.rand = r
+	}
+}
+
+// WithInsecureRand makes the generator read random bits from a math/rand
+// source seeded once at creation, which is cheaper than crypto/rand. The gain
+// per UUID is modest, since reading 16 bytes is only part of the cost, so
+// measure with BenchmarkGeneratorInsecureRand before relying on it.
+//
+// NOT FOR SECURITY-SENSITIVE IDS: the output of math/rand is predictable to
+// anyone who sees a few UUIDs, so use it only where uniqueness is all that
+// matters, such as load tests and high-throughput test harnesses, and never for
+// session tokens, API keys or IDs that must not be guessed. The source is
+// guarded by a mutex, so the generator stays safe for concurrent use.
+func WithInsecureRand() GeneratorOption {
+	return func(g *Generator) {
+		g.rand = &lockedReader{r: mrand.New(mrand.NewSource(insecureSeed()))} //nolint:gosec // opt-in, documented as non-cryptographic
+	}
+}
+
+// insecureSeed returns a seed for WithInsecureRand, so that separate processes
+// do not produce the same sequence.
+func insecureSeed() int64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]))
+}
+
+// lockedReader serializes reads from a reader that is not safe for concurrent
+// use, such as a *math/rand.Rand.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+// Read reads from the underlying reader while holding the lock.
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Read(p)
+}
+
+// WithClock makes the generator read the current time from c instead of the
+// system clock. The generator calls c.Now exactly once per UUID, or once per
+// call to CountryUUIDv8Batch, so a test clock can hand out a scripted time at
+// every step, including times that jump backwards.
+func WithClock(c Clock) GeneratorOption {
+	return func(g *Generator) {
+		g.clock = c
+	}
+}
+
+// WithMonotonic makes the generator guarantee that consecutive UUIDs compare
+// strictly increasing byte-wise.
+//
+// The 12-bit sub-millisecond fraction in bytes 6-7 doubles as a counter: when
+// the clock has not advanced past the previous UUID, the fraction is
+// incremented instead of taken from the clock. A fresh millisecond starts the
+// counter again from the clock's fraction, and once the counter is exhausted the
+// generator moves on to the next millisecond, so the embedded timestamp may run
+// slightly ahead of the wall clock under sustained load of more than 4096 UUIDs
+// per millisecond.
+//
+// If the clock steps backwards, for example after an NTP correction, the
+// generator keeps using the last timestamp it emitted and advances the counter
+// until the clock catches up. CountryUUIDv8WithSource reports when this happens.
+// The output is therefore monotonic even if the clock is not: however the clock
+// jitters, no UUID sorts before or embeds an earlier time than its predecessor.
+// Once the counter reaches the final fraction of MaxTimestamp, generation fails
+// with ErrTimestampOutOfRange rather than wrapping around to the epoch.
+//
+// Every UUID it generates carries the MonotonicFlag option flag, which
+// IsMonotonic reports. Like any option flag it takes byte 11, leaving 32 random
+// bits besides the counter. CountryUUIDv8At, which bypasses the counter, does
+// not set it.
+func WithMonotonic() GeneratorOption {
+	return func(g *Generator) {
+		g.monotonic = true
+	}
+}
+
+// WithPerCountryCounter makes the monotonic counter of WithMonotonic count per
+// country: UUIDs are strictly increasing among those for the same country, but
+// UUIDs for different countries generated in the same instant each start from
+// the clock. That keeps the ordering within every per-country partition dense,
+// without other countries' traffic advancing its counter.
+//
+// It implies WithMonotonic. The generator remembers the last timestamp for
+// every country it has generated a UUID for.
+func WithPerCountryCounter() GeneratorOption {
+	return func(g *Generator) {
+		g.monotonic = true
+		g.perCountry = true
+	}
+}
+
+// WithObserver makes the generator call observe with the embedded country
+// after every UUID it generates successfully, for example to increment a
+// metrics counter labelled by country. Batch generation calls it once per UUID.
+//
+// observe runs synchronously on the generating goroutine, outside of any lock
+// held by the generator, so it must be safe for concurrent use if the generator
+// is shared.
+func WithObserver(observe func(countries.CountryCode)) GeneratorOption {
+	return func(g *Generator) {
+		g.observe = observe
+	}
+}
+
+// WithEpoch makes the generator store timestamps as milliseconds since epoch
+// instead of since the UNIX epoch, truncated to whole milliseconds. Moving the
+// epoch closer to the times being stored shifts the 48-bit range of about 8900
+// years along with it.
+//
+// UUIDs from such a generator embed no trace of the epoch, so their timestamps
+// must be read back with the generator's GetTimestamp and GetTimestampNanos
+// methods; the package-level functions assume the UNIX epoch, which is also why
+// SetDefaultGenerator refuses such a generator. Times before epoch are rejected
+// with an error.
+func WithEpoch(epoch time.Time) GeneratorOption {
+	return func(g *Generator) {
+		g.epochMillis = epoch.UnixMilli()
+	}
+}
+
+// MinCountryBitWidth is the narrowest country field WithCountryBits accepts,
+// enough for every actual country: the largest code, Kosovo's 900, takes 10
+// bits.
+const MinCountryBitWidth = 10
+
+// WithCountryBits narrows the country field to its first n bits, leaving the
+// other CountryBitWidth-n bits of the field to random data. With n = 10 every
+// actual country still fits, while the package's non-country codes, which go
+// up to 999991, need the full width; codes that do not fit in n bits are
+// rejected with ErrInvalidCountryCode.
+//
+// The narrower layout cannot be told apart from the standard one, so mixing
+// them breaks cross-decoding: read the country of UUIDs from such a generator
+// back with its ExtractCountry method only, since the package-level functions
+// assume the full width and SetDefaultGenerator refuses such a generator.
+// Continent-only UUIDs need the full width and are rejected. Generation and the
+// generator's ExtractCountry method fail for n outside MinCountryBitWidth to
+// CountryBitWidth.
+func WithCountryBits(n int) GeneratorOption {
+	return func(g *Generator) {
+		g.countryBits = n
+	}
+}
+
+// entropyPool holds the scratch buffers generate reads random bits into.
+var entropyPool = sync.Pool{
+	New: func() any {
+		return new([16]byte)
+	},
+}
+
+// batchEntropyPool holds the scratch buffers fill reads the random bits of a
+// whole batch into.
+var batchEntropyPool = sync.Pool{
+	New: func() any {
+		return new([]byte)
+	},
+}
+
+// defaultGenerator backs the package-level CountryUUIDv8.
+var defaultGenerator = NewGenerator()
+
+// DefaultGenerator returns the generator behind the package-level functions,
+// so a test that replaces it with SetDefaultGenerator can restore it.
+func DefaultGenerator() *Generator {
+	return defaultGenerator
+}
+
+// SetDefaultGenerator replaces the generator behind the package-level
+// generation functions, such as CountryUUIDv8 and CountryUUIDv8Batch, with g. A
+// nil g installs a fresh generator with the default settings.
+//
+// The package-level decoding functions, such as GetTimestamp, Decode and
+// ExtractCountry, always assume the UNIX epoch and the full country field, so
+// SetDefaultGenerator panics if g was created WithEpoch for another epoch or
+// WithCountryBits for a narrower field: the package-level functions would
+// otherwise misread the UUIDs they generate.
+//
+// It is meant for test setup, to make code that calls CountryUUIDv8 internally
+// deterministic, and is not safe to call concurrently with generation or with
+// other package-level functions:
+//
+//	prev := DefaultGenerator()
+//	SetDefaultGenerator(NewGenerator(WithRand(seeded), WithClock(fixed)))
+//	t.Cleanup(func() { SetDefaultGenerator(prev) })
+func SetDefaultGenerator(g *Generator) {
+	if g == nil {
+		g = NewGenerator()
+	}
+	if g.epochMillis != 0 {
+		panic(fmt.Errorf("uuidv8country: SetDefaultGenerator: generator epoch %s is not the UNIX epoch",
+			time.UnixMilli(g.epochMillis).UTC().Format(time.RFC3339Nano)))
+	}
+	if g.countryBits != CountryBitWidth {
+		panic(fmt.Errorf("uuidv8country: SetDefaultGenerator: generator country field is %d bits, not %d",
+			g.countryBits, CountryBitWidth))
+	}
+	defaultGenerator = g
+}
+
+// Generator produces country UUIDs using a configurable source of entropy and
+// time.
+//
+// A Generator is safe for concurrent use as long as its reader and clock are.
+// The default crypto/rand reader is; math/rand sources are not and must be
+// guarded by the caller. The state of a monotonic generator is guarded
+// internally, so a single one can be shared by any number of goroutines and
+// still hand out strictly increasing UUIDs.
+type Generator struct {
+	rand  io.Reader
+	clock Clock
+
+	monotonic   bool
+	perCountry  bool
+	countryBits int
+	observe     func(countries.CountryCode)
+	epochMillis int64
+	registry    Registry
+
+	// mu guards lastTick and lastTicks, the latter being used instead by
+	// per-country counters
+	mu        sync.Mutex
+	lastTick  uint64
+	lastTicks map[countries.CountryCode]uint64
+}
+
+// NewGenerator returns a Generator configured by opts.
+//
+// Without options it reads random bits from crypto/rand and the time from the
+// system clock, exactly like the package-level CountryUUIDv8. Passing a
+// deterministic reader and a fixed clock makes every UUID reproducible, which
+// is useful in tests:
+//
+//	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(fixedClock))
+//	u, _ := g.CountryUUIDv8(countries.Japan)
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	g := &Generator{
+		rand:        rand.Reader,
+		clock:       systemClock{},
+		countryBits: CountryBitWidth,
+		registry:    DefaultRegistry,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// CountryUUIDv8 generates a UUID version 8 with an embedded country code,
+// drawing random bits from the generator's reader.
+//
+// The layout is identical to the package-level CountryUUIDv8.
+//
+// Returns an error wrapping ErrInvalidCountryCode if country cannot be stored,
+// if the clock reads a time before the generator's epoch or if reading from the
+// random source fails.
+func (g *Generator) CountryUUIDv8(country countries.CountryCode, opts ...Option) (uuid.UUID, error) {
+	u, _, err := g.CountryUUIDv8WithSource(country, opts...)
+	return u, err
+}
+
+// CountryUUIDv8Ctx is like CountryUUIDv8 but returns ctx.Err() without
+// generating anything if ctx is already done.
+//
+// The context is only checked before the random source is read: a read that is
+// already in progress is not interrupted, since io.Reader offers no way to
+// cancel it. Checking costs a single call to ctx.Err.
+func (g *Generator) CountryUUIDv8Ctx(ctx context.Context, country countries.CountryCode, opts ...Option) (uuid.UUID, error) {
+	if err := ctx.Err(); err != nil {
+		return uuid.Nil, err
+	}
+
+	return g.CountryUUIDv8(country, opts...)
+}
+
+// CountryUUIDv8WithTime is like CountryUUIDv8 but also returns the creation
+// time exactly as it was encoded, which g.GetTimestamp returns for the UUID.
+//
+// It is truncated to the millisecond, and for a monotonic generator it includes
+// any spill-over of the counter, so it may differ slightly from the clock
+// reading.
+func (g *Generator) CountryUUIDv8WithTime(country countries.CountryCode, opts ...Option) (uuid.UUID, time.Time, error) {
+	u, err := g.CountryUUIDv8(country, opts...)
+	if err != nil {
+		return uuid.Nil, time.Time{}, err
+	}
+
+	return u, g.GetTimestamp(u), nil
+}
+
+// CountryUUIDv8WithSource is like CountryUUIDv8 but also reports whether the
+// embedded timestamp came from the clock or from the monotonic counter.
+//
+// Generators created without WithMonotonic always report FromClock.
+func (g *Generator) CountryUUIDv8WithSource(country countries.CountryCode, opts ...Option) (uuid.UUID, TimestampSource, error) {
+	tick, err := g.tickAt(g.clock.Now())
+	if err != nil {
+		return uuid.Nil, FromClock, err
+	}
+
+	return g.generate(country, tick, g.monotonic, opts)
+}
+
+// CountryUUIDv8At generates a UUID version 8 with an embedded country code and
+// the given creation time instead of the current time.
+//
+// Only the instant t denotes is stored, so its location does not matter: the
+// same moment in different time zones yields the same timestamp. The monotonic
+// counter is not applied, so the UUID carries exactly the given time even when
+// g is a monotonic generator.
+//
+// Returns an error wrapping ErrTimestampOutOfRange if t is before the
+// generator's epoch, by default the UNIX epoch, or too far after it for the
+// 48-bit timestamp field, or if reading from the random source fails.
+func (g *Generator) CountryUUIDv8At(country countries.CountryCode, t time.Time, opts ...Option) (uuid.UUID, error) {
+	tick, err := g.tickAt(t)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	u, _, err := g.generate(country, tick, false, opts)
+	return u, err
+}
+
+// CountryUUIDv8Batch generates n UUIDs version 8 for the same country using a
+// single read from the generator's random source.
+//
+// All UUIDs in the batch share the same timestamp unless g is a monotonic
+// generator, in which case every element advances the counter.
+//
+// Returns an error if n is negative, if the clock reads a time before the
+// generator's epoch or if reading from the random source fails.
+func (g *Generator) CountryUUIDv8Batch(country countries.CountryCode, n int, opts ...Option) ([]uuid.UUID, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("negative batch size %d", n)
+	}
+
+	result := make([]uuid.UUID, n)
+	if err := g.fill(result, country, opts); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CountryUUIDv8Into is like CountryUUIDv8Batch but fills dst instead of
+// allocating a new slice, generating len(dst) UUIDs in a single read from the
+// generator's random source. Reusing dst across batches, for example from a
+// pool, makes generation allocation-free.
+//
+// It returns the number of UUIDs written, len(dst) on success and 0 on error,
+// in which case the contents of dst are unspecified.
+//
+// Returns an error if dst is empty, if the clock reads a time before the
+// generator's epoch or if reading from the random source fails.
+func (g *Generator) CountryUUIDv8Into(dst []uuid.UUID, country countries.CountryCode, opts ...Option) (int, error) {
+	if len(dst) == 0 {
+		return 0, fmt.Errorf("empty destination slice")
+	}
+
+	if err := g.fill(dst, country, opts); err != nil {
+		return 0, err
+	}
+
+	return len(dst), nil
+}
+
+// fill generates a UUID for country into every element of dst, reading the
+// random bits for all of them in a single read.
+func (g *Generator) fill(dst []uuid.UUID, country countries.CountryCode, opts []Option) error {
+	o := newOptions(opts)
+	if err := o.checkCountry(country); err != nil {
+		return err
+	}
+	if err := g.checkCountryBits(country, &o); err != nil {
+		return err
+	}
+
+	tick, err := g.tickAt(g.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(dst) == 0 {
+		return nil
+	}
+
+	// Read the whole batch at once into a pooled buffer, which only grows
+	// when a batch is larger than any before it
+	buf := batchEntropyPool.Get().(*[]byte)
+	if cap(*buf) < len(dst)*16 {
+		*buf = make([]byte, len(dst)*16)
+	}
+	entropy := (*buf)[:len(dst)*16]
+
+	err = g.readEntropy(entropy)
+	if err == nil {
+		for i := range dst {
+			copy(dst[i][:], entropy[i*16:])
+			if _, err = g.encode(dst[i][:], country, tick, g.monotonic, &o); err != nil {
+				break
+			}
+		}
+	}
+	batchEntropyPool.Put(buf)
+	if err != nil {
+		return err
+	}
+
+	if g.observe != nil {
+		for range dst {
+			g.observe(country)
+		}
+	}
+
+	return nil
+}
+
+// CountryUUIDv8RoundRobin generates n UUIDs version 8, cycling through codes:
+// the i-th UUID embeds codes[i%len(codes)].
+//
+// Every UUID is generated like a separate CountryUUIDv8 call, so a monotonic
+// generator keeps them strictly increasing. All codes are checked before the
+// first UUID is generated.
+//
+// Returns an error if codes is empty, if n is negative, if one of the codes
+// cannot be stored, or under the same conditions as CountryUUIDv8.
+func (g *Generator) CountryUUIDv8RoundRobin(codes []countries.CountryCode, n int, opts ...Option) ([]uuid.UUID, error) {
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("empty country list")
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("negative batch size %d", n)
+	}
+
+	o := newOptions(opts)
+	for _, country := range codes {
+		if err := o.checkCountry(country); err != nil {
+			return nil, err
+		}
+		if err := g.checkCountryBits(country, &o); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]uuid.UUID, n)
+	for i := range result {
+		u, err := g.CountryUUIDv8(codes[i%len(codes)], opts...)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = u
+	}
+
+	return result, nil
+}
+
+// generate assembles a UUID for country created at tick. When sequenced is
+// set, the tick is passed through the monotonic counter first.
+func (g *Generator) generate(country countries.CountryCode, tick uint64, sequenced bool, opts []Option) (uuid.UUID, TimestampSource, error) {
+	o := newOptions(opts)
+	if err := o.checkCountry(country); err != nil {
+		return uuid.Nil, FromClock, err
+	}
+	if err := g.checkCountryBits(country, &o); err != nil {
+		return uuid.Nil, FromClock, err
+	}
+
+	// Read through a pooled buffer: handing a slice of a local array to the
+	// reader interface would move it to the heap on every call
+	buf := entropyPool.Get().(*[16]byte)
+	err := g.readEntropy(buf[:])
+	u := uuid.UUID(*buf)
+	entropyPool.Put(buf)
+	if err != nil {
+		return uuid.Nil, FromClock, err
+	}
+
+	source, err := g.encode(u[:], country, tick, sequenced, &o)
+	if err != nil {
+		return uuid.Nil, FromClock, err
+	}
+
+	if g.observe != nil {
+		g.observe(country)
+	}
+
+	return u, source, nil
+}
+
+// checkCountryBits rejects country if it does not fit a country field narrowed
+// by WithCountryBits.
+func (g *Generator) checkCountryBits(country countries.CountryCode, o *options) error {
+	if g.countryBits == CountryBitWidth {
+		return nil
+	}
+
+	if err := g.checkCountryWidth(); err != nil {
+		return err
+	}
+
+	if o.flags&RegionFlag != 0 {
+		return fmt.Errorf("continent-only UUIDs need the full %d-bit country field, not %d bits",
+			CountryBitWidth, g.countryBits)
+	}
+
+	if country >= 1<<g.countryBits {
+		return fmt.Errorf("%w: %d does not fit in %d bits", ErrInvalidCountryCode, int64(country), g.countryBits)
+	}
+	return nil
+}
+
+// checkCountryWidth rejects a country field width set by WithCountryBits
+// outside MinCountryBitWidth to CountryBitWidth.
+func (g *Generator) checkCountryWidth() error {
+	if g.countryBits < MinCountryBitWidth || g.countryBits > CountryBitWidth {
+		return fmt.Errorf("invalid country field width %d bits, expected %d to %d",
+			g.countryBits, MinCountryBitWidth, CountryBitWidth)
+	}
+	return nil
+}
+
+// ExtractCountry is like the package-level ExtractCountry but reads the
+// country field with the width set by WithCountryBits, so it decodes the UUIDs
+// generated by g.
+//
+// Returns an error if the UUID is not version 8 or is continent-only, or if
+// the generator's country field width is invalid.
+func (g *Generator) ExtractCountry(u uuid.UUID) (countries.CountryCode, error) {
+	if err := g.checkCountryWidth(); err != nil {
+		return countries.Unknown, err
+	}
+
+	code, err := ExtractCountry(u)
+	if err != nil {
+		return countries.Unknown, err
+	}
+
+	return code >> (CountryBitWidth - g.countryBits), nil
+}
+
+// maxEmptyReads is how many reads in a row may return no bytes and no error
+// before readEntropy gives up, as bufio does, instead of spinning forever.
+const maxEmptyReads = 100
+
+// readEntropy fills p from the generator's random source. A source that ends,
+// fails or stops making progress before p is full yields an error wrapping both
+// ErrInsufficientEntropy and the cause, such as io.EOF, so the UUID is never
+// built from partly stale bytes.
+func (g *Generator) readEntropy(p []byte) error {
+	if g.rand == nil {
+		return ErrNoEntropySource
+	}
+
+	for n, empty := 0, 0; n < len(p); {
+		m, err := g.rand.Read(p[n:])
+		n += m
+		if n == len(p) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: read %d of %d bytes: %w", ErrInsufficientEntropy, n, len(p), err)
+		}
+		if m == 0 {
+			if empty++; empty == maxEmptyReads {
+				return fmt.Errorf("%w: %w", ErrInsufficientEntropy, io.ErrNoProgress)
+			}
+		} else {
+			empty = 0
+		}
+	}
+
+	return nil
+}
+
+// encode writes the timestamp, country, version and variant fields, plus any
+// optional fields requested by o, into uuidBytes, which must already hold 16
+// random bytes.
+//
+// Returns an error wrapping ErrTimestampOutOfRange if the monotonic counter
+// has run past the last timestamp the fields can hold.
+func (g *Generator) encode(uuidBytes []byte, country countries.CountryCode, tick uint64, sequenced bool, o *options) (TimestampSource, error) {
+	source := FromClock
+	if sequenced {
+		var err error
+		if tick, source, err = g.next(country, tick); err != nil {
+			return source, err
+		}
+	}
+
+	// Embed 48-bit millisecond timestamp in bytes 0-5 (big-endian)
+	millis := tick >> fractionBits
+	uuidBytes[0] = byte(millis >> 40)
+	uuidBytes[1] = byte(millis >> 32)
+	uuidBytes[2] = byte(millis >> 24)
+	uuidBytes[3] = byte(millis >> 16)
+	uuidBytes[4] = byte(millis >> 8)
+	uuidBytes[5] = byte(millis)
+
+	// Embed 12-bit sub-millisecond fraction in bytes 6-7, below the version
+	fraction := tick & (fractionSteps - 1)
+	uuidBytes[6] = byte(fraction >> 8)
+	uuidBytes[7] = byte(fraction)
+
+	// Embed country code (20 bits is sufficient for all countries)
+	// Use bytes 8-10 for country code, below the variant and flag bits
+	countryCode := uint32(country) & countryMask
+	if shift := CountryBitWidth - g.countryBits; shift > 0 {
+		// A narrowed field keeps random bits below the country
+		random := uint32(uuidBytes[9])<<8 | uint32(uuidBytes[10])
+		countryCode = uint32(country)<<shift | random&(1<<shift-1)
+	}
+	uuidBytes[8] = byte(countryCode >> 16)
+	uuidBytes[9] = byte(countryCode >> 8)
+	uuidBytes[10] = byte(countryCode)
+
+	// Set version 8 (bits 48-51, upper 4 bits of byte 6)
+	uuidBytes[6] = (uuidBytes[6] & 0x0f) | 0x80
+
+	// Set RFC 4122 variant (bits 64-65, upper 2 bits of byte 8)
+	uuidBytes[8] = (uuidBytes[8] & 0x3f) | 0x80
+
+	// The fraction of a monotonic generator is a counter, which IsMonotonic
+	// detects from the flag
+	flags := o.flags
+	if sequenced {
+		flags |= MonotonicFlag
+	}
+
+	// Optional fields turn byte 11 from random data into option flags
+	if flags != 0 {
+		uuidBytes[8] |= extensionBit
+		uuidBytes[OptionsByteOffset] = flags
+
+		if flags&NodeIDFlag != 0 {
+			uuidBytes[NodeIDByteOffset] = o.nodeID
+		}
+	}
+
+	if o.hasPayload {
+		putPayload(uuidBytes, flags, o.payload)
+	}
+
+	if o.reservedZero {
+		uuidBytes[ReservedBitOffset/8] &^= reservedBit
+		if flags != 0 {
+			uuidBytes[OptionsByteOffset] &^= ReservedOptionFlags
+		}
+	}
+
+	// Last, so the checksum covers every other field
+	if flags&ChecksumFlag != 0 {
+		uuidBytes[ChecksumByteOffset] = crc8(uuidBytes[:ChecksumByteOffset])
+	}
+
+	return source, nil
+}
+
+// next returns the tick to embed for a UUID for country generated at tick. It
+// never returns a value lower than or equal to the previous one, which is the
+// previous one for the same country with a per-country counter.
+//
+// Returns an error wrapping ErrTimestampOutOfRange, leaving the counter
+// unchanged, if the previous tick was already maxTick, since the next one would
+// wrap around to the epoch.
+func (g *Generator) next(country countries.CountryCode, tick uint64) (uint64, TimestampSource, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	last := g.lastTick
+	if g.perCountry {
+		last = g.lastTicks[country]
+	}
+
+	source := FromClock
+	if tick <= last {
+		if last == maxTick {
+			return 0, FromCounter, fmt.Errorf("%w: the monotonic counter passed %s", ErrTimestampOutOfRange,
+				time.UnixMilli(g.epochMillis+maxMillis).UTC().Format(time.RFC3339Nano))
+		}
+
+		// Same tick (or the clock went backwards): bump the counter, which
+		// spills into the next millisecond when the fraction wraps around
+		tick, source = last+1, FromCounter
+	}
+
+	if g.perCountry {
+		if g.lastTicks == nil {
+			g.lastTicks = make(map[countries.CountryCode]uint64)
+		}
+		g.lastTicks[country] = tick
+	} else {
+		g.lastTick = tick
+	}
+
+	return tick, source, nil
+}
+
+// tickAt converts t into the 60-bit value stored in the timestamp fields:
+// milliseconds since the generator's epoch followed by a 12-bit
+// sub-millisecond fraction.
+func (g *Generator) tickAt(t time.Time) (uint64, error) {
+	return tickAtEpoch(t, g.epochMillis)
+}
+
+// tickAtEpoch converts t into the 60-bit timestamp value stored by encode,
+// counting milliseconds from epochMillis. The package-level functions pass 0,
+// the epoch of every generator SetDefaultGenerator accepts.
+func tickAtEpoch(t time.Time, epochMillis int64) (uint64, error) {
+	millis := t.UnixMilli() - epochMillis
+	if millis < 0 {
+		return 0, fmt.Errorf("%w: %s is before the epoch %s", ErrTimestampOutOfRange,
+			t.UTC().Format(time.RFC3339Nano), time.UnixMilli(epochMillis).UTC().Format(time.RFC3339Nano))
+	}
+	if millis > maxMillis {
+		return 0, fmt.Errorf("%w: %s is after %s", ErrTimestampOutOfRange,
+			t.UTC().Format(time.RFC3339Nano), time.UnixMilli(epochMillis+maxMillis).UTC().Format(time.RFC3339Nano))
+	}
+
+	fraction := uint64(t.Nanosecond()%int(time.Millisecond)) * fractionSteps / uint64(time.Millisecond)
+	return uint64(millis)<<fractionBits | fraction, nil
+}
+
+// GetTimestamp is like the package-level GetTimestamp but interprets the
+// timestamp relative to the generator's epoch, so it returns the creation time
+// of UUIDs generated by g.
+func (g *Generator) GetTimestamp(u uuid.UUID) time.Time {
+	return timestampAt(u, g.epochMillis)
+}
+
+// GetTimestampNanos is like the package-level GetTimestampNanos but interprets
+// the timestamp relative to the generator's epoch.
+func (g *Generator) GetTimestampNanos(u uuid.UUID) time.Time {
+	return timestampNanosAt(u, g.epochMillis)
+}
+
+// timestampAt reads the millisecond timestamp of u, counted from epochMillis.
+func timestampAt(u uuid.UUID, epochMillis int64) time.Time {
+	return time.UnixMilli(epochMillis + int64(tickFrom(u)>>fractionBits)).UTC()
+}
+
+// timestampNanosAt reads the timestamp of u including its sub-millisecond
+// fraction, counted from epochMillis.
+func timestampNanosAt(u uuid.UUID, epochMillis int64) time.Time {
+	fraction := int64(tickFrom(u) & (fractionSteps - 1))
+	return timestampAt(u, epochMillis).Add(time.Duration(fraction * int64(time.Millisecond) / fractionSteps))
+}
+
+// tickFrom reads the 60-bit timestamp value written by encode back out of u.
+func tickFrom(u uuid.UUID) uint64 {
+	millis := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+		uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+	fraction := uint64(u[6]&0x0f)<<8 | uint64(u[7])
+	return millis<<fractionBits | fraction
+}
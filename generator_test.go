@@ -0,0 +1,903 @@
+package uuidv8country
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	mrand "math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// fakeClock is a Clock whose time only changes when the test sets it.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 22, 10, 30, 45, 0, time.UTC)}
+}
+
+func newSeededGenerator(seed int64) *Generator {
+	return NewGenerator(WithRand(mrand.New(mrand.NewSource(seed))), WithClock(newFakeClock()))
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	sequence := []countries.CountryCode{
+		countries.Russia,
+		countries.USA,
+		countries.Japan,
+		countries.Russia,
+	}
+
+	generate := func() []uuid.UUID {
+		g := newSeededGenerator(1)
+		result := make([]uuid.UUID, 0, len(sequence))
+		for _, country := range sequence {
+			u, err := g.CountryUUIDv8(country)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+			result = append(result, u)
+		}
+		return result
+	}
+
+	first := generate()
+	second := generate()
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("UUID %d differs between runs: %s vs %s", i, first[i], second[i])
+		}
+	}
+
+	if first[0] == first[3] {
+		t.Error("Consecutive UUIDs for the same country should differ")
+	}
+}
+
+func TestGenerator_RoundTrip(t *testing.T) {
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(42))))
+
+	u, err := g.CountryUUIDv8(countries.Germany)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+
+	if version := Version(u); version != 8 {
+		t.Errorf("Version() = %d, expected 8", version)
+	}
+	if !IsRFC4122Variant(u) {
+		t.Errorf("IsRFC4122Variant() = false, expected true")
+	}
+
+	country, err := ExtractCountry(u)
+	if err != nil {
+		t.Fatalf("ExtractCountry() error = %v", err)
+	}
+	if country != countries.Germany {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Germany)
+	}
+}
+
+func TestNewGenerator_Defaults(t *testing.T) {
+	g := NewGenerator()
+
+	if g.rand != rand.Reader {
+		t.Errorf("NewGenerator() reader = %T, expected crypto/rand", g.rand)
+	}
+	if _, ok := g.clock.(systemClock); !ok {
+		t.Errorf("NewGenerator() clock = %T, expected systemClock", g.clock)
+	}
+	if g.monotonic {
+		t.Error("NewGenerator() should not be monotonic by default")
+	}
+
+	u, source, err := g.CountryUUIDv8WithSource(countries.Germany)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8WithSource() error = %v", err)
+	}
+	if source != FromClock {
+		t.Errorf("CountryUUIDv8WithSource() source = %v, expected %v", source, FromClock)
+	}
+	if err := Validate(u); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestNewGenerator_MonotonicWithFakeClock(t *testing.T) {
+	clock := newFakeClock()
+	observed := 0
+	g := NewGenerator(
+		WithRand(mrand.New(mrand.NewSource(1))),
+		WithClock(clock),
+		WithMonotonic(),
+		WithObserver(func(countries.CountryCode) { observed++ }),
+	)
+
+	first, source, _ := g.CountryUUIDv8WithSource(countries.Japan)
+	if source != FromClock {
+		t.Errorf("first source = %v, expected %v", source, FromClock)
+	}
+	if !GetTimestampNanos(first).Equal(clock.now) {
+		t.Errorf("GetTimestampNanos() = %v, expected %v", GetTimestampNanos(first), clock.now)
+	}
+
+	second, source, _ := g.CountryUUIDv8WithSource(countries.Japan)
+	if source != FromCounter {
+		t.Errorf("second source = %v, expected %v", source, FromCounter)
+	}
+	if Compare(first, second) >= 0 {
+		t.Errorf("UUID %s does not sort after %s", second, first)
+	}
+
+	if observed != 2 {
+		t.Errorf("observed = %d, expected 2", observed)
+	}
+}
+
+func TestMonotonicGenerator_SameMillisecond(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithMonotonic(), WithClock(clock))
+
+	// Mix countries so ordering can't come from the country bytes
+	mixed := []countries.CountryCode{countries.USA, countries.Russia, countries.Albania}
+
+	// Generate past the 12-bit counter so it has to spill over
+	us, err := g.CountryUUIDv8RoundRobin(mixed, 3*fractionSteps)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8RoundRobin() error = %v", err)
+	}
+
+	prev := us[0]
+	for i, u := range us[1:] {
+		if bytes.Compare(prev[:], u[:]) >= 0 {
+			t.Fatalf("UUID %d (%s) does not sort after previous (%s)", i+1, u, prev)
+		}
+
+		if version := Version(u); version != 8 {
+			t.Fatalf("Version() = %d, expected 8", version)
+		}
+		if !IsRFC4122Variant(u) {
+			t.Fatalf("IsRFC4122Variant() = false, expected true")
+		}
+
+		prev = u
+	}
+
+	// The counter wrapped twice, spilling two milliseconds ahead
+	want := clock.now.Add(2 * time.Millisecond)
+	if got := GetTimestamp(prev); !got.Equal(want) {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, want)
+	}
+}
+
+func TestMonotonicGenerator_CounterResets(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithMonotonic(), WithClock(clock))
+
+	sequence := func(u uuid.UUID) int {
+		return int(u[6]&0x0f)<<8 | int(u[7])
+	}
+
+	for i := 0; i < 3; i++ {
+		u, err := g.CountryUUIDv8(countries.Japan)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+		if got := sequence(u); got != i {
+			t.Errorf("counter = %d, expected %d", got, i)
+		}
+	}
+
+	clock.now = clock.now.Add(time.Millisecond)
+
+	u, err := g.CountryUUIDv8(countries.Japan)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+	if got := sequence(u); got != 0 {
+		t.Errorf("counter after millisecond advanced = %d, expected 0", got)
+	}
+	if got := GetTimestamp(u); !got.Equal(clock.now) {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, clock.now)
+	}
+}
+
+func TestMonotonicGenerator_ClockRollback(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithMonotonic(), WithClock(clock))
+	start := clock.now
+
+	steps := []struct {
+		clock  time.Time
+		source TimestampSource
+	}{
+		{start, FromClock},
+		{start.Add(5 * time.Millisecond), FromClock},
+		{start.Add(2 * time.Millisecond), FromCounter}, // NTP step backwards
+		{start.Add(3 * time.Millisecond), FromCounter},
+		{start.Add(5 * time.Millisecond), FromCounter},
+		{start.Add(6 * time.Millisecond), FromClock}, // clock caught up
+	}
+
+	var prev uuid.UUID
+	for i, step := range steps {
+		clock.now = step.clock
+
+		u, source, err := g.CountryUUIDv8WithSource(countries.Norway)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8WithSource() error = %v", err)
+		}
+
+		if source != step.source {
+			t.Errorf("step %d: source = %v, expected %v", i, source, step.source)
+		}
+
+		if i > 0 && bytes.Compare(prev[:], u[:]) >= 0 {
+			t.Errorf("step %d: UUID %s does not sort after previous %s", i, u, prev)
+		}
+
+		// Never embed a time earlier than the furthest the clock has been
+		if got := GetTimestamp(u); i > 0 && got.Before(start.Add(5*time.Millisecond)) {
+			t.Errorf("step %d: GetTimestamp() = %v regressed behind the last timestamp", i, got)
+		}
+
+		prev = u
+	}
+}
+
+// scriptedClock is a Clock that returns the next of its times on every call to
+// Now, repeating the last one once they run out.
+type scriptedClock struct {
+	times []time.Time
+	calls int
+}
+
+func (c *scriptedClock) Now() time.Time {
+	t := c.times[min(c.calls, len(c.times)-1)]
+	c.calls++
+	return t
+}
+
+func TestMonotonicGenerator_ClockJitter(t *testing.T) {
+	// A clock that drifts forward but jitters by up to ±3ms on every reading,
+	// with occasional larger NTP-style steps back
+	jitter := mrand.New(mrand.NewSource(7))
+	start := newFakeClock().now
+	clock := &scriptedClock{}
+	for i := 0; i < 5000; i++ {
+		offset := time.Duration(i)*100*time.Microsecond + time.Duration(jitter.Int63n(int64(6*time.Millisecond))) - 3*time.Millisecond
+		if i%500 == 499 {
+			offset -= 20 * time.Millisecond
+		}
+		clock.times = append(clock.times, start.Add(offset))
+	}
+
+	for _, option := range []GeneratorOption{WithMonotonic(), WithPerCountryCounter()} {
+		clock.calls = 0
+		g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(clock), option)
+
+		var prev uuid.UUID
+		var regressions int
+		for i := range clock.times {
+			u, err := g.CountryUUIDv8(countries.Norway)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+			if i > 0 && bytes.Compare(prev[:], u[:]) >= 0 {
+				t.Fatalf("step %d: UUID %s does not sort after previous %s", i, u, prev)
+			}
+			if i > 0 && clock.times[i].Before(clock.times[i-1]) {
+				regressions++
+			}
+			prev = u
+		}
+
+		if clock.calls != len(clock.times) {
+			t.Errorf("Now() called %d times for %d UUIDs, expected once per UUID", clock.calls, len(clock.times))
+		}
+		if regressions == 0 {
+			t.Fatal("test clock never stepped backwards")
+		}
+	}
+}
+
+func TestGenerator_SourceAlwaysClock(t *testing.T) {
+	g := newSeededGenerator(1)
+
+	for i := 0; i < 3; i++ {
+		_, source, err := g.CountryUUIDv8WithSource(countries.Norway)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8WithSource() error = %v", err)
+		}
+		if source != FromClock {
+			t.Errorf("source = %v, expected %v", source, FromClock)
+		}
+	}
+}
+
+func TestGenerator_WithClock(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(clock))
+
+	for _, offset := range []time.Duration{0, time.Hour, 1500 * time.Microsecond} {
+		clock.now = clock.now.Add(offset)
+
+		u, err := g.CountryUUIDv8(countries.Poland)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+
+		want := clock.now.Truncate(time.Millisecond)
+		if got := GetTimestamp(u); !got.Equal(want) {
+			t.Errorf("GetTimestamp() = %v, expected %v", got, want)
+		}
+	}
+}
+
+func TestMonotonicGenerator_Concurrent(t *testing.T) {
+	// Run with -race to check the counter state is guarded
+	const goroutines = 100
+	const uuidsPerGoroutine = 100
+
+	g := NewGenerator(WithMonotonic())
+	results := make([][]uuid.UUID, goroutines)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < uuidsPerGoroutine; j++ {
+				u, err := g.CountryUUIDv8(countries.Portugal)
+				if err != nil {
+					t.Errorf("CountryUUIDv8() error = %v", err)
+					return
+				}
+				results[i] = append(results[i], u)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	ticks := make(map[uint64]bool)
+	for i, us := range results {
+		for j, u := range us {
+			if j > 0 && bytes.Compare(us[j-1][:], u[:]) >= 0 {
+				t.Fatalf("goroutine %d: UUID %d (%s) not after UUID %d (%s)", i, j, u, j-1, us[j-1])
+			}
+
+			tick := tickFrom(u)
+			if ticks[tick] {
+				t.Fatalf("Found duplicate timestamp during concurrent generation: %s", u)
+			}
+			ticks[tick] = true
+		}
+	}
+
+	if len(ticks) != goroutines*uuidsPerGoroutine {
+		t.Errorf("Generated unique UUIDs = %d, expected %d", len(ticks), goroutines*uuidsPerGoroutine)
+	}
+}
+
+func TestPerCountryCounter_SameMillisecond(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithPerCountryCounter(), WithClock(clock))
+
+	// Each country counts on its own, starting from the clock
+	for _, country := range []countries.CountryCode{countries.USA, countries.Russia} {
+		for i := 0; i < 3; i++ {
+			u, source, err := g.CountryUUIDv8WithSource(country)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8WithSource() error = %v", err)
+			}
+
+			wantSource := FromCounter
+			if i == 0 {
+				wantSource = FromClock
+			}
+			if source != wantSource {
+				t.Errorf("%v UUID %d: source = %v, expected %v", country, i, source, wantSource)
+			}
+			if fraction := tickFrom(u) & (fractionSteps - 1); fraction != uint64(i) {
+				t.Errorf("%v UUID %d: fraction = %d, expected %d", country, i, fraction, i)
+			}
+		}
+	}
+}
+
+func TestPerCountryCounter_Concurrent(t *testing.T) {
+	// Run with -race to check the per-country counter state is guarded
+	const goroutines = 50
+	const uuidsPerGoroutine = 200
+
+	mixed := []countries.CountryCode{countries.Portugal, countries.Spain, countries.France}
+	g := NewGenerator(WithPerCountryCounter())
+	results := make([][]uuid.UUID, goroutines)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < uuidsPerGoroutine; j++ {
+				u, err := g.CountryUUIDv8(mixed[(i+j)%len(mixed)])
+				if err != nil {
+					t.Errorf("CountryUUIDv8() error = %v", err)
+					return
+				}
+				results[i] = append(results[i], u)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Within every goroutine, the UUIDs of each country are increasing, and
+	// across all goroutines no two UUIDs of the same country share a timestamp
+	ticks := make(map[countries.CountryCode]map[uint64]bool)
+	for _, country := range mixed {
+		ticks[country] = make(map[uint64]bool)
+	}
+	for i, us := range results {
+		last := make(map[countries.CountryCode]uuid.UUID)
+		for j, u := range us {
+			country := MustExtractCountry(u)
+			if prev, ok := last[country]; ok && bytes.Compare(prev[:], u[:]) >= 0 {
+				t.Fatalf("goroutine %d: %v UUID %d (%s) not after previous (%s)", i, country, j, u, prev)
+			}
+			last[country] = u
+
+			tick := tickFrom(u)
+			if ticks[country][tick] {
+				t.Fatalf("Found duplicate %v timestamp during concurrent generation: %s", country, u)
+			}
+			ticks[country][tick] = true
+		}
+	}
+}
+
+func TestGenerator_WithObserver(t *testing.T) {
+	var mu sync.Mutex
+	counts := make(map[countries.CountryCode]int)
+	g := NewGenerator(WithMonotonic(), WithObserver(func(c countries.CountryCode) {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[c]++
+	}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := g.CountryUUIDv8(countries.Spain); err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+	}
+	if _, err := g.CountryUUIDv8Batch(countries.Italy, 5); err != nil {
+		t.Fatalf("CountryUUIDv8Batch() error = %v", err)
+	}
+
+	// Failed generation is not observed
+	if _, err := g.CountryUUIDv8(countries.Unknown, WithStrictCountry()); !errors.Is(err, ErrUnknownCountry) {
+		t.Fatalf("CountryUUIDv8() error = %v, expected ErrUnknownCountry in strict mode", err)
+	}
+
+	if counts[countries.Spain] != 3 || counts[countries.Italy] != 5 || len(counts) != 2 {
+		t.Errorf("observed counts = %v, expected Spain:3 Italy:5", counts)
+	}
+}
+
+func TestGenerator_ObserverOutsideLock(t *testing.T) {
+	// An observer that generates again would deadlock if called under g.mu
+	var g *Generator
+	nested := 0
+	g = NewGenerator(WithMonotonic(), WithObserver(func(countries.CountryCode) {
+		if nested == 0 {
+			nested++
+			if _, err := g.CountryUUIDv8(countries.Spain); err != nil {
+				t.Errorf("nested CountryUUIDv8() error = %v", err)
+			}
+		}
+	}))
+
+	if _, err := g.CountryUUIDv8(countries.Spain); err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+}
+
+func TestGenerator_WithEpoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock()
+	g := NewGenerator(WithClock(clock), WithEpoch(epoch))
+
+	u, err := g.CountryUUIDv8(countries.Estonia)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+	if got := g.GetTimestamp(u); !got.Equal(clock.now) {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, clock.now)
+	}
+	if got := g.GetTimestampNanos(u); !got.Equal(clock.now) {
+		t.Errorf("GetTimestampNanos() = %v, expected %v", got, clock.now)
+	}
+
+	// Stored relative to the epoch, so the package function is off by it
+	if got, expected := GetTimestamp(u), clock.now.Add(-epoch.Sub(time.Unix(0, 0))); !got.Equal(expected) {
+		t.Errorf("package GetTimestamp() = %v, expected %v", got, expected)
+	}
+
+	// Far beyond the range of the UNIX epoch
+	far := time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)
+	u, err = g.CountryUUIDv8At(countries.Estonia, far)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8At() error = %v", err)
+	}
+	if got := g.GetTimestamp(u); !got.Equal(far) {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, far)
+	}
+}
+
+func TestGenerator_WithEpoch_BeforeEpoch(t *testing.T) {
+	epoch := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithClock(newFakeClock()), WithEpoch(epoch))
+
+	if _, err := g.CountryUUIDv8(countries.Estonia); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("CountryUUIDv8() error = %v, expected ErrTimestampOutOfRange", err)
+	}
+	if _, err := g.CountryUUIDv8Batch(countries.Estonia, 2); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("CountryUUIDv8Batch() error = %v, expected ErrTimestampOutOfRange", err)
+	}
+	if _, err := g.CountryUUIDv8At(countries.Estonia, epoch.Add(-time.Millisecond)); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("CountryUUIDv8At() error = %v, expected ErrTimestampOutOfRange", err)
+	}
+	if _, err := g.CountryUUIDv8At(countries.Estonia, epoch); err != nil {
+		t.Errorf("CountryUUIDv8At() error = %v for the epoch itself", err)
+	}
+}
+
+// countingReader counts the reads from an underlying reader.
+type countingReader struct {
+	r     *mrand.Rand
+	reads int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	c.reads++
+	return c.r.Read(p)
+}
+
+// stalledReader returns no bytes and no error on every read.
+type stalledReader struct{}
+
+func (stalledReader) Read(p []byte) (int, error) {
+	return 0, nil
+}
+
+func TestGenerator_InsufficientEntropy(t *testing.T) {
+	entropy := make([]byte, 40)
+	mrand.New(mrand.NewSource(1)).Read(entropy)
+	g := NewGenerator(WithRand(bytes.NewReader(entropy)), WithClock(newFakeClock()))
+
+	// Two UUIDs fit in 40 bytes; the third finds only 8 left
+	for i := 0; i < 2; i++ {
+		if _, err := g.CountryUUIDv8(countries.Peru); err != nil {
+			t.Fatalf("CountryUUIDv8() #%d error = %v", i, err)
+		}
+	}
+	if _, err := g.CountryUUIDv8(countries.Peru); !errors.Is(err, ErrInsufficientEntropy) || !errors.Is(err, io.EOF) {
+		t.Errorf("CountryUUIDv8() on a short read error = %v, expected ErrInsufficientEntropy and io.EOF", err)
+	}
+	if _, err := g.CountryUUIDv8(countries.Peru); !errors.Is(err, ErrInsufficientEntropy) || !errors.Is(err, io.EOF) {
+		t.Errorf("CountryUUIDv8() on an exhausted reader error = %v, expected ErrInsufficientEntropy and io.EOF", err)
+	}
+
+	batch := NewGenerator(WithRand(bytes.NewReader(entropy)))
+	if us, err := batch.CountryUUIDv8Batch(countries.Peru, 3); !errors.Is(err, ErrInsufficientEntropy) {
+		t.Errorf("CountryUUIDv8Batch() = %v, error = %v, expected ErrInsufficientEntropy", us, err)
+	}
+
+	stalled := NewGenerator(WithRand(stalledReader{}))
+	if _, err := stalled.CountryUUIDv8(countries.Peru); !errors.Is(err, ErrInsufficientEntropy) || !errors.Is(err, io.ErrNoProgress) {
+		t.Errorf("CountryUUIDv8() with a stalled reader error = %v, expected ErrInsufficientEntropy and io.ErrNoProgress", err)
+	}
+}
+
+func TestGenerator_CountryUUIDv8Ctx(t *testing.T) {
+	reader := &countingReader{r: mrand.New(mrand.NewSource(1))}
+	g := NewGenerator(WithRand(reader), WithClock(newFakeClock()))
+
+	u, err := g.CountryUUIDv8Ctx(context.Background(), countries.Peru)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8Ctx() error = %v", err)
+	}
+	if country := MustExtractCountry(u); country != countries.Peru {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Peru)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reads := reader.reads
+	u, err = g.CountryUUIDv8Ctx(ctx, countries.Peru)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CountryUUIDv8Ctx() error = %v, expected context.Canceled", err)
+	}
+	if u != uuid.Nil {
+		t.Errorf("CountryUUIDv8Ctx() = %s, expected uuid.Nil", u)
+	}
+	if reader.reads != reads {
+		t.Errorf("CountryUUIDv8Ctx() read the random source after cancellation")
+	}
+}
+
+func TestCountryUUIDv8RoundRobin(t *testing.T) {
+	codes := []countries.CountryCode{countries.USA, countries.Japan, countries.Kenya}
+
+	us, err := CountryUUIDv8RoundRobin(codes, 7, WithNodeID(4))
+	if err != nil {
+		t.Fatalf("CountryUUIDv8RoundRobin() error = %v", err)
+	}
+	if len(us) != 7 {
+		t.Fatalf("CountryUUIDv8RoundRobin() length = %d, expected 7", len(us))
+	}
+
+	for i, u := range us {
+		if country := MustExtractCountry(u); country != codes[i%len(codes)] {
+			t.Errorf("ExtractCountry(us[%d]) = %v, expected %v", i, country, codes[i%len(codes)])
+		}
+		if id, _ := ExtractNodeID(u); id != 4 {
+			t.Errorf("ExtractNodeID(us[%d]) = %d, expected 4", i, id)
+		}
+	}
+}
+
+func TestCountryUUIDv8RoundRobin_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []countries.CountryCode
+		n     int
+	}{
+		{"Empty", nil, 1},
+		{"Negative", []countries.CountryCode{countries.USA}, -1},
+		{"InvalidCode", []countries.CountryCode{countries.USA, -1}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if us, err := CountryUUIDv8RoundRobin(tt.codes, tt.n); err == nil {
+				t.Errorf("CountryUUIDv8RoundRobin() = %v, expected error", us)
+			}
+		})
+	}
+}
+
+func TestSetDefaultGenerator(t *testing.T) {
+	// Install a seeded generator for the duration of the test and restore the
+	// previous one afterwards, as tests of code calling CountryUUIDv8 would
+	prev := DefaultGenerator()
+	SetDefaultGenerator(newSeededGenerator(1))
+	t.Cleanup(func() { SetDefaultGenerator(prev) })
+
+	first, err := CountryUUIDv8(countries.Denmark)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+
+	SetDefaultGenerator(newSeededGenerator(1))
+	second, _ := CountryUUIDv8(countries.Denmark)
+	if first != second {
+		t.Errorf("CountryUUIDv8() = %s, expected %s from the same seed", second, first)
+	}
+
+	SetDefaultGenerator(nil)
+	if DefaultGenerator() == nil || DefaultGenerator().rand != rand.Reader {
+		t.Error("SetDefaultGenerator(nil) should install a default generator")
+	}
+
+	SetDefaultGenerator(prev)
+	if DefaultGenerator() != prev {
+		t.Error("DefaultGenerator() should return the restored generator")
+	}
+}
+
+func TestSetDefaultGenerator_RejectsOtherLayouts(t *testing.T) {
+	prev := DefaultGenerator()
+	t.Cleanup(func() { SetDefaultGenerator(prev) })
+
+	for name, g := range map[string]*Generator{
+		"epoch":        NewGenerator(WithEpoch(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))),
+		"country bits": NewGenerator(WithCountryBits(MinCountryBitWidth)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("SetDefaultGenerator() should panic")
+				}
+				if DefaultGenerator() != prev {
+					t.Error("SetDefaultGenerator() should leave the default generator in place")
+				}
+			}()
+			SetDefaultGenerator(g)
+		})
+	}
+
+	// The UNIX epoch given explicitly is the default layout
+	SetDefaultGenerator(NewGenerator(WithEpoch(time.Unix(0, 0)), WithCountryBits(CountryBitWidth)))
+
+	u, created, err := CountryUUIDv8WithTime(countries.Denmark)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8WithTime() error = %v", err)
+	}
+	if got := GetTimestamp(u); !got.Equal(created) {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, created)
+	}
+	if country := MustExtractCountry(u); country != countries.Denmark {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Denmark)
+	}
+}
+
+func TestGenerator_NilRand(t *testing.T) {
+	g := NewGenerator(WithRand(nil))
+
+	if _, err := g.CountryUUIDv8(countries.Italy); !errors.Is(err, ErrNoEntropySource) {
+		t.Errorf("CountryUUIDv8() error = %v, expected ErrNoEntropySource", err)
+	}
+	if _, err := g.CountryUUIDv8Batch(countries.Italy, 3); !errors.Is(err, ErrNoEntropySource) {
+		t.Errorf("CountryUUIDv8Batch() error = %v, expected ErrNoEntropySource", err)
+	}
+	if _, err := g.ContinentUUIDv8(countries.RegionEU); !errors.Is(err, ErrNoEntropySource) {
+		t.Errorf("ContinentUUIDv8() error = %v, expected ErrNoEntropySource", err)
+	}
+}
+
+func TestCountryUUIDv8WithTime(t *testing.T) {
+	u, created, err := CountryUUIDv8WithTime(countries.Norway)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8WithTime() error = %v", err)
+	}
+	if !created.Equal(GetTimestamp(u)) {
+		t.Errorf("CountryUUIDv8WithTime() time = %v, expected %v", created, GetTimestamp(u))
+	}
+
+	// The counter of a monotonic generator spills into the next millisecond
+	clock := newFakeClock()
+	epoch := clock.now.Add(-time.Hour)
+	g := NewGenerator(WithMonotonic(), WithClock(clock), WithEpoch(epoch))
+	for i := 0; i <= fractionSteps; i++ {
+		u, created, err = g.CountryUUIDv8WithTime(countries.Norway)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8WithTime() error = %v", err)
+		}
+	}
+	if want := clock.now.Add(time.Millisecond); !created.Equal(want) {
+		t.Errorf("CountryUUIDv8WithTime() time = %v, expected %v", created, want)
+	}
+	if !created.Equal(g.GetTimestamp(u)) {
+		t.Errorf("CountryUUIDv8WithTime() time = %v, expected %v", created, g.GetTimestamp(u))
+	}
+}
+
+func TestGenerator_WithCountryBits(t *testing.T) {
+	for _, bits := range []int{MinCountryBitWidth, 12, CountryBitWidth} {
+		g := NewGenerator(WithCountryBits(bits))
+
+		for _, country := range []countries.CountryCode{countries.Afghanistan, countries.USA, countries.Kosovo, countries.Unknown} {
+			u, err := g.CountryUUIDv8(country, WithChecksum())
+			if err != nil {
+				t.Fatalf("%d bits: CountryUUIDv8(%v) error = %v", bits, country, err)
+			}
+
+			got, err := g.ExtractCountry(u)
+			if err != nil {
+				t.Fatalf("%d bits: ExtractCountry() error = %v", bits, err)
+			}
+			if got != country {
+				t.Errorf("%d bits: ExtractCountry() = %v, expected %v", bits, got, country)
+			}
+			if !VerifyChecksum(u) {
+				t.Errorf("%d bits: VerifyChecksum() = false", bits)
+			}
+		}
+	}
+}
+
+func TestGenerator_WithCountryBits_FreesRandomBits(t *testing.T) {
+	g := NewGenerator(WithCountryBits(MinCountryBitWidth))
+
+	// The low 10 bits of the field vary between UUIDs for the same country
+	seen := make(map[uint32]bool)
+	for i := 0; i < 100; i++ {
+		u, _ := g.CountryUUIDv8(countries.USA)
+		code, _ := ExtractRawCode(u)
+		seen[code&(1<<(CountryBitWidth-MinCountryBitWidth)-1)] = true
+	}
+	if len(seen) < 50 {
+		t.Errorf("low country field bits took %d distinct values in 100 UUIDs, expected random data", len(seen))
+	}
+}
+
+func TestGenerator_WithCountryBits_Errors(t *testing.T) {
+	narrow := NewGenerator(WithCountryBits(MinCountryBitWidth))
+
+	if _, err := narrow.CountryUUIDv8(countries.NonCountryInmarsat); !errors.Is(err, ErrInvalidCountryCode) {
+		t.Errorf("CountryUUIDv8(NonCountryInmarsat) error = %v, expected ErrInvalidCountryCode", err)
+	}
+	if _, err := narrow.CountryUUIDv8Batch(1<<MinCountryBitWidth, 2); !errors.Is(err, ErrInvalidCountryCode) {
+		t.Errorf("CountryUUIDv8Batch(1024) error = %v, expected ErrInvalidCountryCode", err)
+	}
+	if _, err := narrow.ContinentUUIDv8(countries.RegionEU); err == nil {
+		t.Error("ContinentUUIDv8() should return error for a narrowed country field")
+	}
+
+	valid, _ := CountryUUIDv8(countries.USA)
+	for _, bits := range []int{-1, 0, MinCountryBitWidth - 1, CountryBitWidth + 1, 30} {
+		g := NewGenerator(WithCountryBits(bits))
+		if _, err := g.CountryUUIDv8(countries.USA); err == nil {
+			t.Errorf("CountryUUIDv8() with %d country bits should return error", bits)
+		}
+		if _, err := g.ExtractCountry(valid); err == nil {
+			t.Errorf("ExtractCountry() with %d country bits should return error", bits)
+		}
+	}
+}
+
+func TestGenerator_WithInsecureRand(t *testing.T) {
+	g := NewGenerator(WithInsecureRand())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[uuid.UUID]bool)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				u, err := g.CountryUUIDv8(countries.Japan)
+				if err != nil {
+					t.Errorf("CountryUUIDv8() error = %v", err)
+					return
+				}
+				mu.Lock()
+				seen[u] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != 8*500 {
+		t.Errorf("generated %d distinct UUIDs, expected %d", len(seen), 8*500)
+	}
+
+	// Separately created generators are seeded independently
+	a, _ := NewGenerator(WithInsecureRand(), WithClock(newFakeClock())).CountryUUIDv8(countries.Japan)
+	b, _ := NewGenerator(WithInsecureRand(), WithClock(newFakeClock())).CountryUUIDv8(countries.Japan)
+	if a == b {
+		t.Errorf("two WithInsecureRand generators produced the same UUID %s", a)
+	}
+}
+
+func BenchmarkGeneratorCryptoRand(b *testing.B) {
+	g := NewGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.CountryUUIDv8(countries.Russia)
+	}
+}
+
+func BenchmarkGeneratorInsecureRand(b *testing.B) {
+	g := NewGenerator(WithInsecureRand())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.CountryUUIDv8(countries.Russia)
+	}
+}
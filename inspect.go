@@ -0,0 +1,102 @@
+package uuidv8country
+
+import (
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// Decoded describes every field of a country UUID v8, as returned by Inspect.
+type Decoded struct {
+	// Version is the version field, always 8 for a successfully inspected UUID.
+	Version int
+	// Variant is the two variant bits, always binary 10 (RFC 4122).
+	Variant byte
+
+	// Country is the embedded country, or countries.Unknown for continent-only
+	// UUIDs.
+	Country countries.CountryCode
+	// RawCode is the literal value of the country field, as ExtractRawCode
+	// returns it.
+	RawCode uint32
+	// Region is the embedded region for continent-only UUIDs and the region of
+	// Country otherwise.
+	Region countries.RegionCode
+	// ContinentOnly reports whether the UUID was generated by ContinentUUIDv8.
+	ContinentOnly bool
+
+	// Timestamp is the creation time including the sub-millisecond fraction,
+	// as GetTimestampNanos returns it.
+	Timestamp time.Time
+
+	// Flags are the option flags, zero if the extension flag is not set.
+	Flags byte
+	// NodeID is the id set by WithNodeID. It is only meaningful if HasNodeID is
+	// set.
+	NodeID    uint8
+	HasNodeID bool
+	// Monotonic reports whether the UUID came from a monotonic generator, as
+	// IsMonotonic does.
+	Monotonic bool
+	// HasChecksum reports whether the UUID was generated WithChecksum, and
+	// ChecksumValid whether that checksum matches.
+	HasChecksum   bool
+	ChecksumValid bool
+	// Payload is the value ExtractPayload returns, random data unless the UUID
+	// was generated WithPayload.
+	Payload uint64
+}
+
+// Inspect decodes every field of u at once, for debugging tools and admin
+// interfaces. Unlike Validate, it does not reject unknown countries or bad
+// checksums but reports them in the result.
+//
+// For UUIDs from CountryUUIDv8Deterministic only Version, Variant, Country,
+// RawCode and Region are meaningful: Timestamp and Payload hold hash bits, and
+// since the extension flag is always cleared, Flags is zero and no optional
+// field is reported.
+//
+// Example:
+//
+//	d, err := Inspect(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("%v at %v, node %d\n", d.Country, d.Timestamp, d.NodeID)
+//
+// Returns an error if u is not version 8 with the RFC 4122 variant.
+func Inspect(u uuid.UUID) (Decoded, error) {
+	if err := checkVersionVariant(u); err != nil {
+		return Decoded{}, err
+	}
+
+	flags := optionFlags(u)
+	d := Decoded{
+		Version:       Version(u),
+		Variant:       u[VariantByteOffset] >> 6,
+		Country:       countries.CountryCode(rawCode(u)),
+		RawCode:       rawCode(u),
+		ContinentOnly: flags&RegionFlag != 0,
+		Timestamp:     GetTimestampNanos(u),
+		Flags:         flags,
+		HasNodeID:     flags&NodeIDFlag != 0,
+		Monotonic:     flags&MonotonicFlag != 0,
+		HasChecksum:   flags&ChecksumFlag != 0,
+		ChecksumValid: VerifyChecksum(u),
+		Payload:       ExtractPayload(u),
+	}
+
+	if d.ContinentOnly {
+		d.Country = countries.Unknown
+	}
+
+	// A continent-only UUID with a corrupted region field reports no region
+	d.Region, _ = ExtractRegion(u)
+
+	if d.HasNodeID {
+		d.NodeID = u[NodeIDByteOffset]
+	}
+
+	return d, nil
+}
@@ -0,0 +1,77 @@
+package uuidv8country
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestInspect(t *testing.T) {
+	created := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	u, err := CountryUUIDv8At(countries.Kenya, created, WithNodeID(9), WithChecksum(), WithPayload(0xbeef))
+	if err != nil {
+		t.Fatalf("CountryUUIDv8At() error = %v", err)
+	}
+
+	got, err := Inspect(u)
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+
+	want := Decoded{
+		Version:       8,
+		Variant:       0b10,
+		Country:       countries.Kenya,
+		RawCode:       uint32(countries.Kenya),
+		Region:        countries.RegionAF,
+		Timestamp:     created,
+		Flags:         NodeIDFlag | ChecksumFlag,
+		NodeID:        9,
+		HasNodeID:     true,
+		HasChecksum:   true,
+		ChecksumValid: true,
+		Payload:       0xbeef,
+	}
+	if got != want {
+		t.Errorf("Inspect() = %+v, expected %+v", got, want)
+	}
+}
+
+func TestInspect_ContinentOnly(t *testing.T) {
+	u, _ := ContinentUUIDv8(countries.RegionSA)
+
+	got, err := Inspect(u)
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if !got.ContinentOnly || got.Region != countries.RegionSA || got.Country != countries.Unknown {
+		t.Errorf("Inspect() = %+v, expected continent-only %v", got, countries.RegionSA)
+	}
+}
+
+func TestInspect_Deterministic(t *testing.T) {
+	u := CountryUUIDv8Deterministic(countries.Kenya, []byte("key"))
+
+	got, err := Inspect(u)
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if got.Country != countries.Kenya || got.Flags != 0 || got.HasNodeID || got.HasChecksum {
+		t.Errorf("Inspect() = %+v, expected %v without optional fields", got, countries.Kenya)
+	}
+}
+
+func TestInspect_Errors(t *testing.T) {
+	if _, err := Inspect(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("Inspect() error = %v, expected ErrWrongVersion", err)
+	}
+
+	u, _ := CountryUUIDv8(countries.Kenya)
+	u[8] &= 0x3f
+	if _, err := Inspect(u); !errors.Is(err, ErrWrongVariant) {
+		t.Errorf("Inspect() error = %v, expected ErrWrongVariant", err)
+	}
+}
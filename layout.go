@@ -0,0 +1,201 @@
+package uuidv8country
+
+import (
+	"fmt"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// Bit layout of the UUIDs produced by CountryUUIDv8.
+//
+// Bit offsets count from the most significant bit of byte 0, as in RFC 9562
+// diagrams. All multi-byte fields are big-endian. Byte offsets name the byte
+// holding the most significant bit of each field; fields that do not start on a
+// byte boundary share that byte with the preceding field.
+const (
+	// TimestampByteOffset is the first byte of the Unix millisecond timestamp.
+	TimestampByteOffset = 0
+	// TimestampBitOffset is the first bit of the Unix millisecond timestamp.
+	TimestampBitOffset = 0
+	// TimestampBitWidth is the width of the Unix millisecond timestamp.
+	TimestampBitWidth = 48
+
+	// VersionByteOffset is the byte whose upper nibble holds the version.
+	VersionByteOffset = 6
+	// VersionBitOffset is the first bit of the version field.
+	VersionBitOffset = 48
+	// VersionBitWidth is the width of the version field.
+	VersionBitWidth = 4
+
+	// FractionByteOffset is the byte whose lower nibble starts the
+	// sub-millisecond timestamp fraction.
+	FractionByteOffset = 6
+	// FractionBitOffset is the first bit of the sub-millisecond fraction.
+	FractionBitOffset = 52
+	// FractionBitWidth is the width of the sub-millisecond fraction, which
+	// counts in steps of 1/4096 ms.
+	FractionBitWidth = 12
+
+	// VariantByteOffset is the byte whose upper two bits hold the variant.
+	VariantByteOffset = 8
+	// VariantBitOffset is the first bit of the variant field.
+	VariantBitOffset = 64
+	// VariantBitWidth is the width of the variant field.
+	VariantBitWidth = 2
+
+	// ExtensionBitOffset is the flag bit that, when set, marks byte 11 as
+	// holding option flags instead of random data.
+	ExtensionBitOffset = 66
+	// ExtensionBitWidth is the width of the extension flag.
+	ExtensionBitWidth = 1
+
+	// ReservedBitOffset is a bit reserved for future use. It is always zero.
+	ReservedBitOffset = 67
+	// ReservedBitWidth is the width of the reserved bit.
+	ReservedBitWidth = 1
+
+	// CountryByteOffset is the first byte of the country code, which starts
+	// after the variant and flag bits.
+	CountryByteOffset = 8
+	// CountryBitOffset is the first bit of the country code.
+	CountryBitOffset = 68
+	// CountryBitWidth is the width of the country code, wide enough for every
+	// code in the countries package including its non-country codes.
+	CountryBitWidth = 20
+
+	// RandomByteOffset is the first byte of the trailing random data.
+	RandomByteOffset = 11
+	// RandomBitOffset is the first bit of the trailing random data.
+	RandomBitOffset = 88
+	// RandomBitWidth is the width of the trailing random data.
+	RandomBitWidth = 40
+)
+
+// Optional fields. They are only present when the extension flag is set, in
+// which case byte 11 is a set of option flags and the bytes they name are no
+// longer random.
+const (
+	// OptionsByteOffset is the byte holding option flags when the extension
+	// flag is set.
+	OptionsByteOffset = 11
+
+	// NodeIDFlag is the option flag marking a node id in NodeIDByteOffset.
+	NodeIDFlag = 0x80
+	// NodeIDByteOffset is the byte holding the node id set by WithNodeID.
+	NodeIDByteOffset = 12
+
+	// ChecksumFlag is the option flag marking a checksum in ChecksumByteOffset.
+	ChecksumFlag = 0x40
+	// ChecksumByteOffset is the byte holding the CRC-8 of all preceding bytes
+	// set by WithChecksum.
+	ChecksumByteOffset = 15
+
+	// RegionFlag is the option flag marking a continent-only UUID generated by
+	// ContinentUUIDv8, whose country field holds RegionCodeBase plus the
+	// region code instead of a country code.
+	RegionFlag = 0x20
+
+	// MonotonicFlag is the option flag marking a UUID from a generator created
+	// WithMonotonic, whose sub-millisecond fraction doubles as a counter. It
+	// names no field of its own.
+	MonotonicFlag = 0x10
+
+	// ReservedOptionFlags are the option flags not assigned to any optional
+	// field yet, bits 92 to 95. Like the reserved bit they are always zero in
+	// UUIDs generated by this package, and ValidateStrict rejects UUIDs where
+	// they are set.
+	ReservedOptionFlags = 0x0f
+
+	// RegionCodeBase is the start of the range of country field values
+	// reserved for regions: the top 256 values of the 20-bit field, far above
+	// the largest code the countries package assigns (999991), so continent-only
+	// UUIDs never collide with country UUIDs even for readers ignoring the flag.
+	RegionCodeBase = 1<<CountryBitWidth - 256
+)
+
+const (
+	// extensionBit is the extension flag within byte 8.
+	extensionBit = 0x80 >> (ExtensionBitOffset % 8)
+	// reservedBit is the reserved bit within byte 8.
+	reservedBit = 0x80 >> (ReservedBitOffset % 8)
+
+	// countryMask selects the bits of a country code that fit the field.
+	countryMask = 1<<CountryBitWidth - 1
+)
+
+// optionFlags returns the option flags of u, or zero if the extension flag is
+// not set.
+func optionFlags(u [16]byte) byte {
+	if u[ExtensionBitOffset/8]&extensionBit == 0 {
+		return 0
+	}
+	return u[OptionsByteOffset]
+}
+
+// Layout locates the country field in UUIDs v8 whose producer places it
+// somewhere other than this package does, so ExtractCountryAt can decode them.
+// Offsets count bits from the most significant bit of byte 0, like the layout
+// constants above.
+type Layout struct {
+	// CountryBitOffset is the first bit of the country code.
+	CountryBitOffset int
+
+	// CountryBitWidth is the width of the country code, from 1 to 32 bits.
+	CountryBitWidth int
+}
+
+// DefaultLayout is the layout of the UUIDs produced by CountryUUIDv8.
+var DefaultLayout = Layout{
+	CountryBitOffset: CountryBitOffset,
+	CountryBitWidth:  CountryBitWidth,
+}
+
+// validate reports an error if the country field of l does not fit a UUID.
+func (l Layout) validate() error {
+	if l.CountryBitWidth < 1 || l.CountryBitWidth > 32 {
+		return fmt.Errorf("invalid layout: country field width %d, expected 1 to 32 bits", l.CountryBitWidth)
+	}
+	if l.CountryBitOffset < 0 || l.CountryBitOffset+l.CountryBitWidth > 128 {
+		return fmt.Errorf("invalid layout: country field at bits %d to %d does not fit 128 bits",
+			l.CountryBitOffset, l.CountryBitOffset+l.CountryBitWidth-1)
+	}
+	return nil
+}
+
+// ExtractCountryAt extracts the country code from a UUID v8 whose country field
+// is where layout says, for UUIDs from partners that embed the country
+// differently. The field is read as a big-endian unsigned integer and returned
+// without checking it against the countries package, like ExtractCountry.
+//
+// With DefaultLayout it is equivalent to ExtractCountry, including the
+// ErrContinentOnly error for continent-only UUIDs. Other layouts know nothing
+// of this package's option flags.
+//
+// Example:
+//
+//	partner := Layout{CountryBitOffset: 96, CountryBitWidth: 16}
+//	country, err := ExtractCountryAt(u, partner)
+//
+// Returns an error if the UUID is not version 8 or the layout does not fit a
+// UUID.
+func ExtractCountryAt(u uuid.UUID, layout Layout) (countries.CountryCode, error) {
+	if version := Version(u); version != 8 {
+		return countries.Unknown, versionErrors[version]
+	}
+
+	if layout == DefaultLayout {
+		return ExtractCountry(u)
+	}
+
+	if err := layout.validate(); err != nil {
+		return countries.Unknown, err
+	}
+
+	var code uint32
+	for i := layout.CountryBitOffset; i < layout.CountryBitOffset+layout.CountryBitWidth; i++ {
+		code = code<<1 | uint32(u[i/8]>>(7-i%8)&1)
+	}
+
+	return countries.CountryCode(code), nil
+}
@@ -0,0 +1,165 @@
+package uuidv8country
+
+import (
+	"errors"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// readBits returns width bits of u starting at bit offset, counted from the
+// most significant bit of byte 0.
+func readBits(u uuid.UUID, offset, width int) uint64 {
+	var v uint64
+	for i := offset; i < offset+width; i++ {
+		bit := (u[i/8] >> (7 - uint(i%8))) & 1
+		v = v<<1 | uint64(bit)
+	}
+	return v
+}
+
+func TestLayout_MatchesEncoding(t *testing.T) {
+	created := time.Date(2025, 5, 17, 6, 45, 12, 500*int(time.Microsecond), time.UTC)
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(7))))
+
+	u, err := g.CountryUUIDv8At(countries.Kosovo, created)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8At() error = %v", err)
+	}
+
+	if got := readBits(u, TimestampBitOffset, TimestampBitWidth); got != uint64(created.UnixMilli()) {
+		t.Errorf("timestamp field = %d, expected %d", got, created.UnixMilli())
+	}
+	if got := readBits(u, VersionBitOffset, VersionBitWidth); got != 8 {
+		t.Errorf("version field = %d, expected 8", got)
+	}
+	if got := readBits(u, FractionBitOffset, FractionBitWidth); got != 1<<FractionBitWidth/2 {
+		t.Errorf("fraction field = %d, expected %d", got, 1<<FractionBitWidth/2)
+	}
+	if got := readBits(u, VariantBitOffset, VariantBitWidth); got != 2 {
+		t.Errorf("variant field = %02b, expected 10", got)
+	}
+	if got := readBits(u, CountryBitOffset, CountryBitWidth); got != uint64(countries.Kosovo) {
+		t.Errorf("country field = %d, expected %d", got, countries.Kosovo)
+	}
+	if got := readBits(u, ExtensionBitOffset, ExtensionBitWidth+ReservedBitWidth); got != 0 {
+		t.Errorf("flag bits = %02b, expected 00", got)
+	}
+
+	withNode, err := g.CountryUUIDv8At(countries.Kosovo, created, WithNodeID(0x5a))
+	if err != nil {
+		t.Fatalf("CountryUUIDv8At() error = %v", err)
+	}
+	if got := readBits(withNode, ExtensionBitOffset, ExtensionBitWidth); got != 1 {
+		t.Errorf("extension flag = %d, expected 1", got)
+	}
+	if got := readBits(withNode, OptionsByteOffset*8, 8); got != NodeIDFlag {
+		t.Errorf("option flags = %08b, expected %08b", got, NodeIDFlag)
+	}
+	if got := readBits(withNode, NodeIDByteOffset*8, 8); got != 0x5a {
+		t.Errorf("node id field = %#x, expected 0x5a", got)
+	}
+}
+
+func TestLayout_Consistency(t *testing.T) {
+	fields := []struct {
+		name                   string
+		byteOffset, bit, width int
+	}{
+		{"Timestamp", TimestampByteOffset, TimestampBitOffset, TimestampBitWidth},
+		{"Version", VersionByteOffset, VersionBitOffset, VersionBitWidth},
+		{"Fraction", FractionByteOffset, FractionBitOffset, FractionBitWidth},
+		{"Variant", VariantByteOffset, VariantBitOffset, VariantBitWidth},
+		{"Extension", ExtensionBitOffset / 8, ExtensionBitOffset, ExtensionBitWidth},
+		{"Reserved", ReservedBitOffset / 8, ReservedBitOffset, ReservedBitWidth},
+		{"Country", CountryByteOffset, CountryBitOffset, CountryBitWidth},
+		{"Random", RandomByteOffset, RandomBitOffset, RandomBitWidth},
+	}
+
+	// Fields are listed in order and must tile all 128 bits without gaps
+	next := 0
+	for _, f := range fields {
+		if f.bit != next {
+			t.Errorf("%s starts at bit %d, expected %d", f.name, f.bit, next)
+		}
+		if f.byteOffset != f.bit/8 {
+			t.Errorf("%s byte offset = %d, expected %d", f.name, f.byteOffset, f.bit/8)
+		}
+		next = f.bit + f.width
+	}
+	if next != 128 {
+		t.Errorf("fields cover %d bits, expected 128", next)
+	}
+
+	// The widest code in the countries package must fit the country field
+	for _, c := range countries.AllNonCountries() {
+		if uint64(c) >= 1<<CountryBitWidth {
+			t.Errorf("country code %d does not fit in %d bits", c, CountryBitWidth)
+		}
+	}
+}
+
+func TestLayout_OptionFlags(t *testing.T) {
+	// Every option flag is either assigned to one field or reserved
+	assigned := []byte{NodeIDFlag, ChecksumFlag, RegionFlag, MonotonicFlag}
+
+	var seen byte = ReservedOptionFlags
+	for _, flag := range assigned {
+		if seen&flag != 0 {
+			t.Errorf("option flag %#02x overlaps another flag", flag)
+		}
+		seen |= flag
+	}
+	if seen != 0xff {
+		t.Errorf("option flags cover %#02x, expected 0xff", seen)
+	}
+}
+
+func TestExtractCountryAt(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Germany, WithNodeID(3))
+	continent, _ := ContinentUUIDv8(countries.RegionEU)
+
+	if got, err := ExtractCountryAt(u, DefaultLayout); err != nil || got != countries.Germany {
+		t.Errorf("ExtractCountryAt(DefaultLayout) = %v, %v, expected %v", got, err, countries.Germany)
+	}
+	if _, err := ExtractCountryAt(continent, DefaultLayout); !errors.Is(err, ErrContinentOnly) {
+		t.Errorf("ExtractCountryAt(continent, DefaultLayout) error = %v, expected ErrContinentOnly", err)
+	}
+
+	// A partner UUID v8 with a 16-bit country code in bytes 12-13
+	partner := uuid.UUID(u)
+	partner[12], partner[13] = 0x01, 0x88 // 392, Japan
+	layout := Layout{CountryBitOffset: 96, CountryBitWidth: 16}
+	if got, err := ExtractCountryAt(partner, layout); err != nil || got != countries.Japan {
+		t.Errorf("ExtractCountryAt(partner) = %v, %v, expected %v", got, err, countries.Japan)
+	}
+
+	// A field that does not start on a byte boundary
+	unaligned := Layout{CountryBitOffset: 100, CountryBitWidth: 10}
+	code := readBits(partner, 100, 10)
+	if got, _ := ExtractCountryAt(partner, unaligned); uint64(got) != code {
+		t.Errorf("ExtractCountryAt(unaligned) = %d, expected %d", got, code)
+	}
+}
+
+func TestExtractCountryAt_Errors(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Germany)
+
+	if _, err := ExtractCountryAt(uuid.New(), DefaultLayout); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCountryAt(v4) error = %v, expected ErrWrongVersion", err)
+	}
+
+	for _, layout := range []Layout{
+		{CountryBitOffset: 96, CountryBitWidth: 0},
+		{CountryBitOffset: 64, CountryBitWidth: 33},
+		{CountryBitOffset: -1, CountryBitWidth: 8},
+		{CountryBitOffset: 121, CountryBitWidth: 8},
+	} {
+		if _, err := ExtractCountryAt(u, layout); err == nil {
+			t.Errorf("ExtractCountryAt(%+v) should return error", layout)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package uuidv8country
+
+import (
+	"fmt"
+
+	"github.com/biter777/countries"
+)
+
+// Option configures a single call to CountryUUIDv8 or one of its variants.
+type Option func(*options)
+
+// options holds the optional fields requested for a UUID.
+type options struct {
+	flags  byte
+	nodeID uint8
+
+	payload    uint64
+	hasPayload bool
+
+	strict       bool
+	reservedZero bool
+}
+
+// newOptions applies opts to a zero options value.
+func newOptions(opts []Option) options {
+	// Options take a pointer, which moves o to the heap, so only pay for that
+	// when there are any
+	if len(opts) == 0 {
+		return options{}
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// checkCountry rejects country if it cannot be stored in the country field,
+// or if strict country checking was requested and it is not an actual country.
+func (o *options) checkCountry(country countries.CountryCode) error {
+	// Region codes are stored in their reserved range on purpose
+	if o.flags&RegionFlag != 0 {
+		return nil
+	}
+
+	if !isStorableCountry(country) {
+		return fmt.Errorf("%w: %d", ErrInvalidCountryCode, int64(country))
+	}
+
+	if o.strict && !isRealCountry(country) {
+		return fmt.Errorf("%w: code %d", ErrUnknownCountry, int(country))
+	}
+	return nil
+}
+
+// WithNodeID embeds a one-byte node or shard id, which ExtractNodeID returns.
+//
+// The id takes the place of random bits, leaving 24 random bits besides the
+// timestamp fraction. Version, variant and country are unaffected.
+func WithNodeID(id uint8) Option {
+	return func(o *options) {
+		o.flags |= NodeIDFlag
+		o.nodeID = id
+	}
+}
+
+// WithChecksum stores a CRC-8 of the first 15 bytes in the last byte, which
+// VerifyChecksum checks. It detects every single mangled character and most
+// other corruption, and tells these UUIDs apart from foreign v8 UUIDs, which
+// pass only about once in 1000.
+//
+// The checksum and the option flags byte both take the place of random bits,
+// so only 24 random bits remain besides the timestamp fraction, or 16 when
+// combined with WithNodeID. That is still plenty to keep concurrently generated
+// UUIDs for the same country apart, but does make them easier to guess.
+func WithChecksum() Option {
+	return func(o *options) {
+		o.flags |= ChecksumFlag
+	}
+}
+
+// WithPayload stores an application-defined value in the random bits left over
+// by the other fields, which ExtractPayload returns.
+//
+// Without other options 40 bits are available, bytes 11 to 15. Any other
+// option, or a generator created WithMonotonic, takes byte 11 for the option
+// flags, leaving 32 bits, and WithNodeID and WithChecksum take another 8 bits
+// each. Bits of payload beyond the available width are dropped, so only the low
+// 16 bits survive combining it with both.
+//
+// The payload replaces all remaining random bits, so UUIDs generated within the
+// same 1/4096 ms for the same country and payload are only kept apart by a
+// monotonic generator.
+func WithPayload(payload uint64) Option {
+	return func(o *options) {
+		o.payload = payload
+		o.hasPayload = true
+	}
+}
+
+// WithReservedZero guarantees that the reserved bit and the
+// ReservedOptionFlags are zero, so the UUID passes ValidateStrict.
+//
+// Generation currently leaves them zero anyway; the option pins that down for
+// callers relying on ValidateStrict in case later versions fill reserved bits
+// with random data.
+func WithReservedZero() Option {
+	return func(o *options) {
+		o.reservedZero = true
+	}
+}
+
+// WithStrictCountry makes generation fail with ErrUnknownCountry instead of
+// embedding countries.Unknown, a code the countries package does not recognize,
+// or one of its placeholder and non-country codes. It accepts exactly the
+// countries HasKnownCountry reports.
+//
+// Without it, any code is embedded as is.
+func WithStrictCountry() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
@@ -0,0 +1,85 @@
+package uuidv8country
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestWithNodeID(t *testing.T) {
+	uuidMap := make(map[uuid.UUID]bool)
+
+	for _, id := range []uint8{0, 1, 42, 255} {
+		for i := 0; i < 100; i++ {
+			u, err := CountryUUIDv8(countries.Finland, WithNodeID(id))
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+
+			if uuidMap[u] {
+				t.Fatalf("Found duplicate UUID: %s", u)
+			}
+			uuidMap[u] = true
+
+			node, err := ExtractNodeID(u)
+			if err != nil {
+				t.Fatalf("ExtractNodeID() error = %v", err)
+			}
+			if node != id {
+				t.Errorf("ExtractNodeID() = %d, expected %d", node, id)
+			}
+
+			if err := Validate(u); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if country := MustExtractCountry(u); country != countries.Finland {
+				t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Finland)
+			}
+		}
+	}
+}
+
+func TestExtractNodeID_NotSet(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Finland)
+	if _, err := ExtractNodeID(u); !errors.Is(err, ErrNoNodeID) {
+		t.Errorf("ExtractNodeID() error = %v, expected ErrNoNodeID", err)
+	}
+
+	if _, err := ExtractNodeID(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractNodeID() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestWithStrictCountry(t *testing.T) {
+	u, err := CountryUUIDv8(countries.Chile, WithStrictCountry())
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+	if country := MustExtractCountry(u); country != countries.Chile {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Chile)
+	}
+
+	rejected := []countries.CountryCode{
+		countries.Unknown,
+		countries.None,
+		countries.International,
+		countries.NonCountryInmarsat,
+		countries.CountryCode(1),
+		countries.CountryCode(RegionCodeBase - 1),
+	}
+	for _, country := range rejected {
+		if _, err := CountryUUIDv8(country, WithStrictCountry()); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("CountryUUIDv8(%d) error = %v, expected ErrUnknownCountry", country, err)
+		}
+		if _, err := CountryUUIDv8Batch(country, 3, WithStrictCountry()); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("CountryUUIDv8Batch(%d) error = %v, expected ErrUnknownCountry", country, err)
+		}
+
+		// Lenient by default
+		if _, err := CountryUUIDv8(country); err != nil {
+			t.Errorf("CountryUUIDv8(%d) error = %v, expected nil", country, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package uuidv8country
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Compare orders two UUIDs generated by CountryUUIDv8 chronologically.
+//
+// UUIDs are ordered primarily by their embedded timestamp, including the
+// sub-millisecond fraction that a monotonic generator uses as its counter, and
+// secondarily by the remaining bytes (country code and random data). This
+// matches the order in which a monotonic generator emits UUIDs.
+//
+// The result is -1 if a sorts before b, 0 if they are equal and +1 otherwise.
+// The version and variant fields are not validated.
+func Compare(a, b uuid.UUID) int {
+	ta, tb := tickFrom(a), tickFrom(b)
+
+	switch {
+	case ta < tb:
+		return -1
+	case ta > tb:
+		return 1
+	}
+
+	return bytes.Compare(a[8:], b[8:])
+}
+
+// Less reports whether a sorts before b in the order defined by Compare: by
+// embedded timestamp first, then by the remaining bytes as a tie-breaker. It
+// suits container/heap and sort.Slice, while Compare itself can be passed to
+// slices.SortFunc.
+func Less(a, b uuid.UUID) bool {
+	return Compare(a, b) < 0
+}
+
+// SortByTime sorts us in place in the order defined by Compare.
+func SortByTime(us []uuid.UUID) {
+	sort.Slice(us, func(i, j int) bool {
+		return Less(us[i], us[j])
+	})
+}
+
+// IsChronological reports whether the embedded timestamps of us never
+// decrease, including the sub-millisecond fraction, as they should not for
+// UUIDs written in the order a monotonic generator emitted them. Equal
+// timestamps are allowed. If us is out of order, the index returned is that of
+// the first UUID whose timestamp is earlier than its predecessor's; otherwise
+// it is -1.
+//
+// A UUID that is not version 8 with the RFC 4122 variant has no timestamp to
+// compare, so it too stops the check: IsChronological returns false and its
+// index.
+//
+// Example:
+//
+//	if ok, i := IsChronological(written); !ok {
+//		t.Fatalf("UUID %d (%s) is out of order", i, written[i])
+//	}
+func IsChronological(us []uuid.UUID) (bool, int) {
+	var prev uint64
+	for i, u := range us {
+		if Version(u) != 8 || !IsRFC4122Variant(u) {
+			return false, i
+		}
+
+		tick := tickFrom(u)
+		if i > 0 && tick < prev {
+			return false, i
+		}
+		prev = tick
+	}
+
+	return true, -1
+}
+
+// SortKeyLength is the length in bytes of the keys returned by SortKey: 6 for
+// the millisecond timestamp, then 4 packing the 12-bit sub-millisecond fraction
+// with the 20-bit country field.
+const SortKeyLength = 10
+
+// SortKey returns the part of u that orders it by time and then by country, for
+// use as a compact key in a key-value store or secondary index. Keys compare
+// byte-wise first by embedded timestamp, including the sub-millisecond
+// fraction, then by country code, so a range scan over a key prefix covers a
+// time window. The version, variant, flag bits and all random data are
+// dropped, which means UUIDs for the same country within the same 1/4096 ms
+// share a key.
+//
+// The key is always SortKeyLength bytes long. Continent-only UUIDs sort after
+// every country within the same instant. The version is not validated.
+//
+// Example:
+//
+//	db.Put(append(SortKey(u), u[:]...), event)
+func SortKey(u uuid.UUID) []byte {
+	key := make([]byte, SortKeyLength)
+	copy(key, u[:6])
+
+	fraction := uint32(u[6]&0x0f)<<8 | uint32(u[7])
+	binary.BigEndian.PutUint32(key[6:], fraction<<CountryBitWidth|rawCode(u))
+
+	return key
+}
+
+// SameCountryAndTime reports whether a and b embed the same country and the
+// same millisecond timestamp, ignoring the sub-millisecond fraction, the
+// monotonic counter and all random bits. It detects near-duplicate events, such
+// as the same event regenerated within a millisecond.
+//
+// Continent-only UUIDs match only UUIDs for the same region.
+//
+// Returns an error if either UUID is not version 8.
+func SameCountryAndTime(a, b uuid.UUID) (bool, error) {
+	ca, err := ExtractRawCode(a)
+	if err != nil {
+		return false, err
+	}
+
+	cb, err := ExtractRawCode(b)
+	if err != nil {
+		return false, err
+	}
+
+	return ca == cb && tickFrom(a)>>fractionBits == tickFrom(b)>>fractionBits, nil
+}
+
+// EqualConstantTime reports whether a and b are equal, taking the same time
+// whether or not they are and wherever they differ. Use it when a UUID serves
+// as an opaque capability token, so comparing a guess against it leaks nothing
+// through timing.
+//
+// Plain == is the right choice whenever timing is not a concern, such as for
+// ids that are not secrets.
+func EqualConstantTime(a, b uuid.UUID) bool {
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
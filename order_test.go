@@ -0,0 +1,291 @@
+package uuidv8country
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestCompare(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(clock))
+
+	earlier, _ := g.CountryUUIDv8(countries.Sweden)
+	clock.now = clock.now.Add(time.Microsecond)
+	later, _ := g.CountryUUIDv8(countries.Austria) // lower country code than Sweden
+
+	if got := Compare(earlier, later); got != -1 {
+		t.Errorf("Compare(earlier, later) = %d, expected -1", got)
+	}
+	if got := Compare(later, earlier); got != 1 {
+		t.Errorf("Compare(later, earlier) = %d, expected 1", got)
+	}
+	if got := Compare(earlier, earlier); got != 0 {
+		t.Errorf("Compare(earlier, earlier) = %d, expected 0", got)
+	}
+
+	// Same timestamp: fall back to the remaining bytes
+	a, _ := g.CountryUUIDv8(countries.Austria)
+	b, _ := g.CountryUUIDv8(countries.Sweden)
+	if got := Compare(a, b); got != -1 {
+		t.Errorf("Compare() with equal timestamps = %d, expected -1", got)
+	}
+}
+
+func TestSortByTime(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithMonotonic(), WithClock(clock))
+
+	want := make([]uuid.UUID, 0, 100)
+	for i := 0; i < 100; i++ {
+		if i%10 == 0 {
+			clock.now = clock.now.Add(time.Millisecond)
+		}
+		u, err := g.CountryUUIDv8(countries.All()[i])
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+		want = append(want, u)
+	}
+
+	shuffled := append([]uuid.UUID(nil), want...)
+	mrand.New(mrand.NewSource(2)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	SortByTime(shuffled)
+
+	for i := range want {
+		if shuffled[i] != want[i] {
+			t.Fatalf("SortByTime()[%d] = %s, expected %s", i, shuffled[i], want[i])
+		}
+	}
+}
+
+func TestByteOrderMatchesTimeOrder(t *testing.T) {
+	// Real clock across sleeps, with countries in descending order so that
+	// only the timestamp can put them in order
+	us := make([]uuid.UUID, 0, 10)
+	all := countries.All()
+	for i := 0; i < 10; i++ {
+		u, err := CountryUUIDv8(all[len(all)-1-i])
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+		us = append(us, u)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	for i := 1; i < len(us); i++ {
+		if bytes.Compare(us[i-1][:], us[i][:]) >= 0 {
+			t.Errorf("bytes.Compare(%s, %s) >= 0, expected earlier UUID to sort first", us[i-1], us[i])
+		}
+	}
+
+	// Timestamps on both sides of every byte carry of the millisecond field
+	clock := newFakeClock()
+	g := NewGenerator(WithClock(clock))
+	for shift := 0; shift < TimestampBitWidth; shift += 8 {
+		clock.now = time.UnixMilli(1<<shift - 1)
+		before, _ := g.CountryUUIDv8(countries.Zimbabwe)
+		clock.now = time.UnixMilli(1 << shift)
+		after, _ := g.CountryUUIDv8(countries.Afghanistan)
+
+		if bytes.Compare(before[:], after[:]) >= 0 {
+			t.Errorf("bytes.Compare(%s, %s) >= 0 across 2^%d ms", before, after, shift)
+		}
+	}
+}
+
+func TestIsChronological(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(clock))
+
+	var us []uuid.UUID
+	for i := 0; i < 4; i++ {
+		u, _ := g.CountryUUIDv8(countries.Sweden)
+		us = append(us, u)
+		clock.now = clock.now.Add(time.Millisecond)
+	}
+	same, _ := g.CountryUUIDv8(countries.Austria)
+	sameTime := append([]uuid.UUID{same}, same)
+
+	tests := []struct {
+		name      string
+		us        []uuid.UUID
+		wantOK    bool
+		wantIndex int
+	}{
+		{"Empty", nil, true, -1},
+		{"Single", us[:1], true, -1},
+		{"Ordered", us, true, -1},
+		{"EqualTimestamps", sameTime, true, -1},
+		{"Backwards", []uuid.UUID{us[0], us[2], us[1], us[3]}, false, 2},
+		{"Version4", []uuid.UUID{us[0], uuid.New(), us[1]}, false, 1},
+		{"NilFirst", []uuid.UUID{uuid.Nil, us[0]}, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, i := IsChronological(tt.us)
+			if ok != tt.wantOK || i != tt.wantIndex {
+				t.Errorf("IsChronological() = (%v, %d), expected (%v, %d)", ok, i, tt.wantOK, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(clock))
+
+	sweden, _ := g.CountryUUIDv8(countries.Sweden, WithNodeID(7))
+	austria, _ := g.CountryUUIDv8(countries.Austria)
+	clock.now = clock.now.Add(time.Microsecond)
+	later, _ := g.CountryUUIDv8(countries.Austria)
+
+	key := SortKey(sweden)
+	if len(key) != SortKeyLength {
+		t.Fatalf("len(SortKey()) = %d, expected %d", len(key), SortKeyLength)
+	}
+	if !bytes.Equal(key[:6], sweden[:6]) {
+		t.Errorf("SortKey()[:6] = %x, expected timestamp %x", key[:6], sweden[:6])
+	}
+
+	// Same instant: ordered by country, whatever the flags
+	if bytes.Compare(SortKey(austria), SortKey(sweden)) >= 0 {
+		t.Errorf("SortKey(Austria) should sort before SortKey(Sweden) at the same time")
+	}
+	// Time comes first, even with a lower country code
+	if bytes.Compare(SortKey(sweden), SortKey(later)) >= 0 {
+		t.Errorf("SortKey() of an earlier UUID should sort before a later one")
+	}
+
+	// Random data is dropped
+	again, _ := g.CountryUUIDv8(countries.Austria)
+	if !bytes.Equal(SortKey(later), SortKey(again)) {
+		t.Errorf("SortKey() = %x and %x, expected equal keys for the same time and country", SortKey(later), SortKey(again))
+	}
+}
+
+func TestSameCountryAndTime(t *testing.T) {
+	created := time.Date(2025, 5, 5, 12, 0, 0, 0, time.UTC)
+	a, _ := CountryUUIDv8At(countries.Spain, created)
+	sameMilli, _ := CountryUUIDv8At(countries.Spain, created.Add(999*time.Microsecond), WithNodeID(1))
+	nextMilli, _ := CountryUUIDv8At(countries.Spain, created.Add(time.Millisecond))
+	otherCountry, _ := CountryUUIDv8At(countries.Portugal, created)
+
+	tests := []struct {
+		name string
+		b    uuid.UUID
+		want bool
+	}{
+		{"Itself", a, true},
+		{"SameMillisecond", sameMilli, true},
+		{"NextMillisecond", nextMilli, false},
+		{"OtherCountry", otherCountry, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SameCountryAndTime(a, tt.b)
+			if err != nil {
+				t.Fatalf("SameCountryAndTime() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SameCountryAndTime() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameCountryAndTime_NotV8(t *testing.T) {
+	a, _ := CountryUUIDv8(countries.Spain)
+
+	if _, err := SameCountryAndTime(a, uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("SameCountryAndTime() error = %v, expected ErrWrongVersion", err)
+	}
+	if _, err := SameCountryAndTime(uuid.Nil, a); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("SameCountryAndTime() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+// uuidHeap is a min-heap of UUIDs ordered by Less.
+type uuidHeap []uuid.UUID
+
+func (h uuidHeap) Len() int           { return len(h) }
+func (h uuidHeap) Less(i, j int) bool { return Less(h[i], h[j]) }
+func (h uuidHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *uuidHeap) Push(x any)        { *h = append(*h, x.(uuid.UUID)) }
+func (h *uuidHeap) Pop() any {
+	old := *h
+	u := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return u
+}
+
+func TestLess(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(clock))
+
+	earlier, _ := g.CountryUUIDv8(countries.Sweden)
+	clock.now = clock.now.Add(time.Microsecond)
+	later, _ := g.CountryUUIDv8(countries.Austria)
+
+	if !Less(earlier, later) {
+		t.Error("Less(earlier, later) = false, expected true")
+	}
+	if Less(later, earlier) {
+		t.Error("Less(later, earlier) = true, expected false")
+	}
+	if Less(earlier, earlier) {
+		t.Error("Less(earlier, earlier) = true, expected false")
+	}
+}
+
+func TestLess_Heap(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithClock(clock))
+
+	want := make([]uuid.UUID, 20)
+	for i := range want {
+		clock.now = clock.now.Add(time.Millisecond)
+		want[i], _ = g.CountryUUIDv8(countries.All()[20-i])
+	}
+
+	h := &uuidHeap{}
+	for _, i := range mrand.New(mrand.NewSource(2)).Perm(len(want)) {
+		heap.Push(h, want[i])
+	}
+
+	for i := range want {
+		if got := heap.Pop(h).(uuid.UUID); got != want[i] {
+			t.Errorf("heap.Pop() #%d = %s, expected %s", i, got, want[i])
+		}
+	}
+}
+
+func TestEqualConstantTime(t *testing.T) {
+	a, _ := CountryUUIDv8(countries.Spain)
+
+	for i := range a {
+		b := a
+		b[i] ^= 0x01
+		if EqualConstantTime(a, b) {
+			t.Errorf("EqualConstantTime() = true for UUIDs differing in byte %d", i)
+		}
+	}
+
+	if !EqualConstantTime(a, a) {
+		t.Error("EqualConstantTime(a, a) = false, expected true")
+	}
+	if !EqualConstantTime(uuid.Nil, Zero) {
+		t.Error("EqualConstantTime(uuid.Nil, Zero) = false, expected true")
+	}
+}
@@ -0,0 +1,47 @@
+package uuidv8country
+
+import "github.com/google/uuid"
+
+// ExtractPayload returns the value stored by WithPayload, read from the random
+// bits the option flags of u leave over. See WithPayload for their width.
+//
+// The result is meaningless for UUIDs generated without WithPayload, which
+// hold random data in these bits, and for UUIDs from other generators, which
+// are not checked.
+func ExtractPayload(u uuid.UUID) uint64 {
+	start, end := payloadBytes(optionFlags(u))
+
+	var payload uint64
+	for _, b := range u[start:end] {
+		payload = payload<<8 | uint64(b)
+	}
+	return payload
+}
+
+// putPayload stores the low bits of payload in the payload bytes of uuidBytes
+// for the given option flags.
+func putPayload(uuidBytes []byte, flags byte, payload uint64) {
+	start, end := payloadBytes(flags)
+	for i := end - 1; i >= start; i-- {
+		uuidBytes[i] = byte(payload)
+		payload >>= 8
+	}
+}
+
+// payloadBytes returns the range of bytes not taken by the optional fields
+// flags names.
+func payloadBytes(flags byte) (start, end int) {
+	start, end = RandomByteOffset, len(uuid.UUID{})
+	if flags == 0 {
+		return start, end
+	}
+
+	start = OptionsByteOffset + 1
+	if flags&NodeIDFlag != 0 {
+		start = NodeIDByteOffset + 1
+	}
+	if flags&ChecksumFlag != 0 {
+		end = ChecksumByteOffset
+	}
+	return start, end
+}
@@ -0,0 +1,67 @@
+package uuidv8country
+
+import (
+	"testing"
+
+	"github.com/biter777/countries"
+)
+
+func TestWithPayload(t *testing.T) {
+	const payload = 0xfedcba9876543210
+
+	tests := []struct {
+		name string
+		opts []Option
+		bits uint
+	}{
+		{"Alone", nil, 40},
+		{"WithNodeID", []Option{WithNodeID(7)}, 24},
+		{"WithChecksum", []Option{WithChecksum()}, 24},
+		{"WithBoth", []Option{WithNodeID(7), WithChecksum()}, 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithPayload(payload)}, tt.opts...)
+			u, err := CountryUUIDv8(countries.Chile, opts...)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+
+			want := uint64(payload) & (1<<tt.bits - 1)
+			if got := ExtractPayload(u); got != want {
+				t.Errorf("ExtractPayload() = %#x, expected %#x", got, want)
+			}
+			if country := MustExtractCountry(u); country != countries.Chile {
+				t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Chile)
+			}
+			if !IsCountryUUIDv8(u) {
+				t.Errorf("IsCountryUUIDv8(%s) = false, expected true", u)
+			}
+		})
+	}
+}
+
+func TestWithPayload_KeepsOptionalFields(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Chile, WithPayload(1<<40-1), WithNodeID(42), WithChecksum())
+
+	if id, err := ExtractNodeID(u); err != nil || id != 42 {
+		t.Errorf("ExtractNodeID() = %d, %v, expected 42, <nil>", id, err)
+	}
+	if !VerifyChecksum(u) {
+		t.Errorf("VerifyChecksum(%s) = false, expected true", u)
+	}
+}
+
+func TestWithPayload_Batch(t *testing.T) {
+	us, err := CountryUUIDv8Batch(countries.Chile, 10, WithPayload(12345))
+	if err != nil {
+		t.Fatalf("CountryUUIDv8Batch() error = %v", err)
+	}
+
+	for _, u := range us {
+		if got := ExtractPayload(u); got != 12345 {
+			t.Errorf("ExtractPayload() = %d, expected 12345", got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package uuidv8country
+
+import (
+	"fmt"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// Registry maps country names to the codes stored in the country field and
+// back, so applications with their own country table, including custom
+// entries such as "EU", can use it in place of the countries package.
+//
+// Implementations must be safe for concurrent use if the generator using them
+// is shared.
+type Registry interface {
+	// Encode returns the code for name, or an error if name is not in the
+	// registry.
+	Encode(name string) (uint16, error)
+
+	// Decode returns the name for code, or an error if code is not in the
+	// registry.
+	Decode(code uint16) (string, error)
+}
+
+// DefaultRegistry is the Registry backed by the countries package, used by
+// generators created without WithRegistry.
+//
+// Encode resolves names like CountryUUIDv8FromName, accepting only actual
+// countries, and Decode returns the English name of any code the countries
+// package recognizes.
+var DefaultRegistry Registry = countriesRegistry{}
+
+// countriesRegistry implements DefaultRegistry.
+type countriesRegistry struct{}
+
+// Encode resolves name with countries.ByName.
+func (countriesRegistry) Encode(name string) (uint16, error) {
+	country := countries.ByName(name)
+	if !isRealCountry(country) {
+		return 0, fmt.Errorf("%w: name %q", ErrUnknownCountry, name)
+	}
+	return uint16(country), nil
+}
+
+// Decode returns the name the countries package gives code.
+func (countriesRegistry) Decode(code uint16) (string, error) {
+	country := countries.CountryCode(code)
+	if !country.IsValid() {
+		return "", fmt.Errorf("%w: code %d", ErrUnknownCountry, code)
+	}
+	return country.String(), nil
+}
+
+// WithRegistry makes the generator's CountryUUIDv8FromName and
+// ExtractCountryName methods translate names through r instead of the countries
+// package. A nil r selects DefaultRegistry.
+//
+// The codes r assigns are stored as is. The other generator methods still take
+// countries.CountryCode values, to which codes from r convert directly, and
+// WithStrictCountry still checks codes against the countries package, so it
+// rejects custom codes unknown to it.
+func WithRegistry(r Registry) GeneratorOption {
+	return func(g *Generator) {
+		if r == nil {
+			r = DefaultRegistry
+		}
+		g.registry = r
+	}
+}
+
+// CountryUUIDv8FromName generates a UUID version 8 for the code the
+// generator's registry assigns to name.
+//
+// Example:
+//
+//	g := NewGenerator(WithRegistry(myRegistry))
+//	u, err := g.CountryUUIDv8FromName("EU")
+//
+// Returns the registry's error if it does not know name, or an error if
+// generation fails as for CountryUUIDv8.
+func (g *Generator) CountryUUIDv8FromName(name string, opts ...Option) (uuid.UUID, error) {
+	code, err := g.registry.Encode(name)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return g.CountryUUIDv8(countries.CountryCode(code), opts...)
+}
+
+// ExtractCountryName extracts the code from a UUID v8 generated by g and
+// returns the name the generator's registry gives it.
+//
+// Unlike the package-level ExtractCountryName, which yields "Unknown" for
+// codes the countries package does not recognize, it reports codes missing
+// from the registry as an error.
+//
+// Returns an error if the UUID is not version 8 or is continent-only, or the
+// registry's error if it does not know the code.
+func (g *Generator) ExtractCountryName(u uuid.UUID) (string, error) {
+	country, err := g.ExtractCountry(u)
+	if err != nil {
+		return "", err
+	}
+
+	if country > 1<<16-1 {
+		return "", fmt.Errorf("%w: code %d is outside the registry range", ErrUnknownCountry, int64(country))
+	}
+
+	return g.registry.Decode(uint16(country))
+}
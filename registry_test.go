@@ -0,0 +1,88 @@
+package uuidv8country
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// mapRegistry is a tiny Registry with custom entries.
+type mapRegistry map[string]uint16
+
+var errNotRegistered = errors.New("not registered")
+
+func (r mapRegistry) Encode(name string) (uint16, error) {
+	code, ok := r[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errNotRegistered, name)
+	}
+	return code, nil
+}
+
+func (r mapRegistry) Decode(code uint16) (string, error) {
+	for name, c := range r {
+		if c == code {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %d", errNotRegistered, code)
+}
+
+func TestGenerator_WithRegistry(t *testing.T) {
+	registry := mapRegistry{"EU": 1000, "Germany": 276}
+	g := NewGenerator(WithRegistry(registry))
+
+	for name, code := range registry {
+		u, err := g.CountryUUIDv8FromName(name, WithNodeID(1))
+		if err != nil {
+			t.Fatalf("CountryUUIDv8FromName(%q) error = %v", name, err)
+		}
+		if raw, _ := ExtractRawCode(u); raw != uint32(code) {
+			t.Errorf("ExtractRawCode() = %d, expected %d", raw, code)
+		}
+		if got, err := g.ExtractCountryName(u); err != nil || got != name {
+			t.Errorf("ExtractCountryName() = %q, %v, expected %q", got, err, name)
+		}
+	}
+
+	if _, err := g.CountryUUIDv8FromName("France"); !errors.Is(err, errNotRegistered) {
+		t.Errorf("CountryUUIDv8FromName(France) error = %v, expected the registry's error", err)
+	}
+
+	france, _ := CountryUUIDv8(countries.France)
+	if _, err := g.ExtractCountryName(france); !errors.Is(err, errNotRegistered) {
+		t.Errorf("ExtractCountryName(France) error = %v, expected the registry's error", err)
+	}
+	inmarsat, _ := CountryUUIDv8(countries.NonCountryInmarsat)
+	if _, err := g.ExtractCountryName(inmarsat); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("ExtractCountryName(NonCountryInmarsat) error = %v, expected ErrUnknownCountry", err)
+	}
+	if _, err := g.ExtractCountryName(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCountryName(v4) error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	for _, g := range []*Generator{NewGenerator(), NewGenerator(WithRegistry(nil))} {
+		u, err := g.CountryUUIDv8FromName("germany")
+		if err != nil {
+			t.Fatalf("CountryUUIDv8FromName() error = %v", err)
+		}
+		if country := MustExtractCountry(u); country != countries.Germany {
+			t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Germany)
+		}
+		if name, err := g.ExtractCountryName(u); err != nil || name != "Germany" {
+			t.Errorf("ExtractCountryName() = %q, %v, expected Germany", name, err)
+		}
+	}
+
+	if _, err := DefaultRegistry.Encode("Atlantis"); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("Encode(Atlantis) error = %v, expected ErrUnknownCountry", err)
+	}
+	if _, err := DefaultRegistry.Decode(1); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("Decode(1) error = %v, expected ErrUnknownCountry", err)
+	}
+}
@@ -0,0 +1,53 @@
+package uuidv8country
+
+import (
+	"context"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// StreamCountryUUIDv8 sends freshly generated country UUIDs to out until ctx is
+// cancelled, using the package's default generator.
+//
+// It blocks, so it is usually run in its own goroutine with any number of
+// workers receiving from out:
+//
+//	ids := make(chan uuid.UUID, 64)
+//	go func() {
+//		_ = StreamCountryUUIDv8(ctx, countries.Spain, ids)
+//	}()
+//
+// The channel is not closed when streaming stops, since several streams may
+// share it.
+//
+// Returns ctx.Err() once the context is cancelled, or the error from random
+// number generation if that fails first.
+func StreamCountryUUIDv8(ctx context.Context, country countries.CountryCode, out chan<- uuid.UUID) error {
+	return defaultGenerator.Stream(ctx, country, out)
+}
+
+// Stream sends UUIDs generated by g to out until ctx is cancelled. See
+// StreamCountryUUIDv8 for details.
+//
+// A pending send is abandoned as soon as ctx is done, so Stream never blocks
+// forever on an unbuffered channel nobody receives from.
+func (g *Generator) Stream(ctx context.Context, country countries.CountryCode, out chan<- uuid.UUID) error {
+	for {
+		// Check first: select picks randomly when out is also ready
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		u, err := g.CountryUUIDv8(country)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case out <- u:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package uuidv8country
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestStreamCountryUUIDv8_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan uuid.UUID)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- StreamCountryUUIDv8(ctx, countries.Spain, out)
+	}()
+
+	seen := make(map[uuid.UUID]bool)
+	for i := 0; i < 100; i++ {
+		u := <-out
+		if seen[u] {
+			t.Fatalf("Found duplicate UUID in stream: %s", u)
+		}
+		seen[u] = true
+
+		if country := MustExtractCountry(u); country != countries.Spain {
+			t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Spain)
+		}
+	}
+
+	// Stop receiving and cancel: the pending send must be abandoned
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("StreamCountryUUIDv8() error = %v, expected context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("StreamCountryUUIDv8() did not return after cancellation")
+	}
+}
+
+func TestStreamCountryUUIDv8_AlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A buffered channel is always ready, so only the context check stops it
+	out := make(chan uuid.UUID, 1)
+	if err := StreamCountryUUIDv8(ctx, countries.Spain, out); !errors.Is(err, context.Canceled) {
+		t.Errorf("StreamCountryUUIDv8() error = %v, expected context.Canceled", err)
+	}
+	if len(out) != 0 {
+		t.Errorf("StreamCountryUUIDv8() sent %d UUIDs after cancellation", len(out))
+	}
+}
@@ -6,26 +6,46 @@
 package uuidv8country
 
 import (
-	"crypto/rand"
-	"encoding/binary"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/biter777/countries"
 	"github.com/google/uuid"
 )
 
+// UnknownAlpha2 is the alpha-2 code reported for UUIDs whose embedded country
+// has no ISO 3166-1 alpha-2 representation, such as countries.Unknown.
+// "XX" is reserved by ISO 3166-1 for user-assigned use and never names a country.
+const UnknownAlpha2 = "XX"
+
 // CountryUUIDv8 generates a UUID version 8 with an embedded country code.
 //
 // The UUID structure is as follows:
-//   - Bytes 0-7: Unix timestamp in nanoseconds (big-endian)
-//   - Bytes 8-10: Country code (3 bytes, accounting for RFC 4122 variant bits)
+//   - Bytes 0-5: Unix timestamp in milliseconds (48 bits, big-endian)
+//   - Bytes 6-7: Sub-millisecond fraction of the timestamp (12 bits, ~244ns resolution)
+//   - Bytes 8-10: Country code (20 bits, below the RFC 4122 variant and two flag bits)
 //   - Bytes 11-15: Random data
 //   - Byte 6: Version field (upper 4 bits set to 8)
 //   - Byte 8: Variant field (upper 2 bits set to 10 for RFC 4122)
 //
+// Options such as WithNodeID store additional fields. When any is used, the
+// extension flag in byte 8 is set and byte 11 holds option flags describing
+// which of the following random bytes were repurposed.
+//
+// The country code is stored as the integer value of countries.CountryCode.
+// For every ISO 3166-1 country this is its standard numeric code (for example
+// 840 for the United States), so other systems can decode it without the
+// countries package; see ExtractNumericCode. countries.Unknown is stored as
+// zero like any other code, so the result is still a valid UUID v8 with a
+// timestamp and random bits, distinct from uuid.Nil; see IsUnknownCountry.
+//
+// Because the timestamp occupies the most significant bytes, UUIDs generated in
+// different milliseconds sort in creation order.
+//
 // The function uses cryptographically secure random number generator for
-// random portions of the UUID.
+// random portions of the UUID. Use a Generator to supply a different source.
+// It is safe for concurrent use by multiple goroutines.
 //
 // Example:
 //
@@ -35,39 +55,247 @@ import (
 //	}
 //	fmt.Println(u) // Output: xxxxxxxx-xxxx-8xxx-xxxx-xxxxxxxxxxxx
 //
-// Returns an error if random number generation fails.
-func CountryUUIDv8(country countries.CountryCode) (uuid.UUID, error) {
-	var uuidBytes [16]byte
+// Any code that fits the country field is accepted, including countries.Unknown
+// and codes the countries package does not recognize; use WithStrictCountry to
+// reject those. Returns ErrInvalidCountryCode, wrapped, if the code is negative
+// or too large to be stored, or an error if random number generation fails.
+func CountryUUIDv8(country countries.CountryCode, opts ...Option) (uuid.UUID, error) {
+	return defaultGenerator.CountryUUIDv8(country, opts...)
+}
 
-	if _, err := rand.Read(uuidBytes[:]); err != nil {
-		return uuid.Nil, err
+// CountryUUIDv8WithTime is like CountryUUIDv8 but also returns the creation
+// time encoded in the UUID, in UTC and truncated to the millisecond. It always
+// equals what GetTimestamp later decodes, so it can be written to an audit row
+// alongside the UUID.
+//
+// Example:
+//
+//	u, created, err := CountryUUIDv8WithTime(countries.Norway)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(created.Equal(GetTimestamp(u))) // Output: true
+//
+// Returns an error under the same conditions as CountryUUIDv8.
+func CountryUUIDv8WithTime(country countries.CountryCode, opts ...Option) (uuid.UUID, time.Time, error) {
+	return defaultGenerator.CountryUUIDv8WithTime(country, opts...)
+}
+
+// CountryUUIDv8At generates a UUID version 8 with an embedded country code and
+// a caller-supplied creation time, for example when backfilling historical
+// records.
+//
+// The time is stored with sub-millisecond precision: GetTimestamp returns t
+// truncated to the millisecond, and GetTimestampNanos returns it to within 244ns.
+//
+// Example:
+//
+//	created := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+//	u, err := CountryUUIDv8At(countries.France, created)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(GetTimestamp(u).Equal(created)) // Output: true
+//
+// Returns an error wrapping ErrTimestampOutOfRange if t is before the UNIX
+// epoch or after MaxTimestamp, or if random number generation fails.
+func CountryUUIDv8At(country countries.CountryCode, t time.Time, opts ...Option) (uuid.UUID, error) {
+	return defaultGenerator.CountryUUIDv8At(country, t, opts...)
+}
+
+// CountryUUIDv8Batch generates n UUIDs version 8 for the same country.
+//
+// All random bits for the batch are read from the cryptographically secure
+// random number generator in a single call, which is considerably cheaper than
+// calling CountryUUIDv8 in a loop. The UUIDs share a millisecond timestamp but
+// remain unique through their random portions.
+//
+// Example:
+//
+//	us, err := CountryUUIDv8Batch(countries.India, 1000)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(len(us)) // Output: 1000
+//
+// Returns an error if n is negative or if random number generation fails.
+func CountryUUIDv8Batch(country countries.CountryCode, n int, opts ...Option) ([]uuid.UUID, error) {
+	return defaultGenerator.CountryUUIDv8Batch(country, n, opts...)
+}
+
+// CountryUUIDv8Into fills dst with UUIDs version 8 for the same country,
+// reading all their random bits in a single call like CountryUUIDv8Batch, but
+// without allocating: the caller owns and can reuse dst.
+//
+// Example:
+//
+//	buf := make([]uuid.UUID, 64)
+//	n, err := CountryUUIDv8Into(buf, countries.India)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(n) // Output: 64
+//
+// Returns the number of UUIDs written, or an error if dst is empty or if
+// random number generation fails.
+func CountryUUIDv8Into(dst []uuid.UUID, country countries.CountryCode, opts ...Option) (int, error) {
+	return defaultGenerator.CountryUUIDv8Into(dst, country, opts...)
+}
+
+// CountryUUIDv8RoundRobin generates n UUIDs version 8, cycling through codes,
+// for fixtures and load tests that need a spread of countries.
+//
+// Example:
+//
+//	us, err := CountryUUIDv8RoundRobin([]countries.CountryCode{countries.USA, countries.Japan}, 4)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(MustExtractCountry(us[2])) // Output: United States
+//
+// Returns an error if codes is empty, if n is negative, if one of the codes
+// cannot be stored or if random number generation fails.
+func CountryUUIDv8RoundRobin(codes []countries.CountryCode, n int, opts ...Option) ([]uuid.UUID, error) {
+	return defaultGenerator.CountryUUIDv8RoundRobin(codes, n, opts...)
+}
+
+// CountryUUIDv8FromAlpha2 generates a UUID version 8 for the country identified
+// by an ISO 3166-1 alpha-2 code such as "US" or "DE".
+//
+// The code is trimmed and matched case-insensitively. Once resolved, the UUID is
+// produced exactly as by CountryUUIDv8.
+//
+// Example:
+//
+//	u, err := CountryUUIDv8FromAlpha2("de")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	country, _ := ExtractCountry(u)
+//	fmt.Println(country) // Output: Germany
+//
+// Returns an error wrapping ErrUnknownCountry if the code is empty or is not a
+// known alpha-2 code, or an error if random number generation fails.
+func CountryUUIDv8FromAlpha2(code string) (uuid.UUID, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return uuid.Nil, fmt.Errorf("%w: empty alpha-2 code", ErrUnknownCountry)
 	}
 
-	timestamp := uint64(time.Now().UnixNano())
+	// ByName also accepts alpha-3 codes and names, so make sure the input
+	// really was the alpha-2 form of the resolved country
+	country := countries.ByName(code)
+	if len(code) != 2 || country.Alpha2() != code {
+		return uuid.Nil, fmt.Errorf("%w: alpha-2 code %q", ErrUnknownCountry, code)
+	}
 
-	binary.BigEndian.PutUint64(uuidBytes[0:8], timestamp)
+	return CountryUUIDv8(country)
+}
 
-	// Embed country code (3 bytes is sufficient for all countries)
-	// Use bytes 8-10 for country code
-	countryCode := uint32(country)
-	uuidBytes[8] = byte(countryCode >> 16)
-	uuidBytes[9] = byte(countryCode >> 8)
-	uuidBytes[10] = byte(countryCode)
+// CountryUUIDv8FromAlpha3 generates a UUID version 8 for the country identified
+// by an ISO 3166-1 alpha-3 code such as "USA" or "DEU".
+//
+// The code is trimmed and matched case-insensitively, so "usa" and "USA" are
+// equivalent. The embedded code is the same one CountryUUIDv8 stores for the
+// resolved country, so the result round-trips through ExtractCountry.
+//
+// Example:
+//
+//	u, err := CountryUUIDv8FromAlpha3("deu")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	country, _ := ExtractCountry(u)
+//	fmt.Println(country) // Output: Germany
+//
+// Returns an error wrapping ErrUnknownCountry if the code is empty or is not a
+// known alpha-3 code, or an error if random number generation fails.
+func CountryUUIDv8FromAlpha3(code string) (uuid.UUID, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return uuid.Nil, fmt.Errorf("%w: empty alpha-3 code", ErrUnknownCountry)
+	}
 
-	// Set version 8 (bits 48-51, upper 4 bits of byte 6)
-	uuidBytes[6] = (uuidBytes[6] & 0x0f) | 0x80
+	country := countries.ByName(code)
+	if len(code) != 3 || country.Alpha3() != code {
+		return uuid.Nil, fmt.Errorf("%w: alpha-3 code %q", ErrUnknownCountry, code)
+	}
 
-	// Set RFC 4122 variant (bits 64-65, upper 2 bits of byte 8)
-	uuidBytes[8] = (uuidBytes[8] & 0x3f) | 0x80
+	return CountryUUIDv8(country)
+}
 
-	u, err := uuid.FromBytes(uuidBytes[:])
+// CountryUUIDv8FromName generates a UUID version 8 for the country with the
+// given English name, such as "Germany" or "united states".
+//
+// The name is resolved with countries.ByName, which ignores case and
+// surrounding whitespace and knows many common alternatives ("Russia", "Ivory
+// Coast", "Burma"). It also accepts alpha-2 and alpha-3 codes. Historical names
+// of countries that no longer exist, such as "Czechoslovakia", fail to resolve,
+// and ambiguous names resolve to whichever country the countries package
+// prefers, for example "Korea" to the Republic of Korea.
+//
+// ExtractCountryName returns the canonical spelling of the resolved country,
+// which may differ from name.
+//
+// Example:
+//
+//	u, err := CountryUUIDv8FromName("germany")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	name, _ := ExtractCountryName(u)
+//	fmt.Println(name) // Output: Germany
+//
+// Returns an error wrapping ErrUnknownCountry if the name does not resolve to
+// a country, or an error if random number generation fails.
+func CountryUUIDv8FromName(name string) (uuid.UUID, error) {
+	country := countries.ByName(name)
+	if !isRealCountry(country) {
+		return uuid.Nil, fmt.Errorf("%w: name %q", ErrUnknownCountry, name)
+	}
+
+	return CountryUUIDv8(country)
+}
+
+// Parse parses s as a UUID and checks that it is a UUID v8 in the format
+// produced by CountryUUIDv8.
+//
+// Any form accepted by uuid.Parse is allowed, including every style produced
+// by Format, but unlike uuid.Parse the result is guaranteed to carry version 8
+// and the RFC 4122 variant.
+//
+// Example:
+//
+//	u, err := Parse("018d1234-5678-8abc-bdef-0123456789ab")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	country, _ := ExtractCountry(u)
+//
+// Returns an error if s is not a valid UUID, or if it has the wrong version or variant.
+func Parse(s string) (uuid.UUID, error) {
+	u, err := uuid.Parse(s)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
+	if err := checkVersionVariant(u); err != nil {
+		return uuid.Nil, err
+	}
+
 	return u, nil
 }
 
+// MustParse is like Parse but panics if s cannot be parsed as a country UUID v8.
+// It simplifies initialization of test fixtures and global variables.
+func MustParse(s string) uuid.UUID {
+	u, err := Parse(s)
+	if err != nil {
+		panic(`uuidv8country: Parse(` + s + `): ` + err.Error())
+	}
+	return u
+}
+
 // ExtractCountry extracts the country code from a UUID v8 generated by CountryUUIDv8.
 //
 // The function validates that the provided UUID is version 8 before attempting
@@ -83,27 +311,358 @@ func CountryUUIDv8(country countries.CountryCode) (uuid.UUID, error) {
 //	}
 //	fmt.Println(country) // Output: Germany
 //
-// Returns an error if the UUID is not version 8.
+// Returns an error if the UUID is not version 8, or ErrContinentOnly if it was
+// generated by ContinentUUIDv8 and embeds only a region; see ExtractRegion.
 func ExtractCountry(u uuid.UUID) (countries.CountryCode, error) {
-	uuidBytes := u[:]
+	countryCode, err := ExtractRawCode(u)
+	if err != nil {
+		return countries.Unknown, err
+	}
 
-	// Validate version
-	version := (uuidBytes[6] & 0xf0) >> 4
-	if version != 8 {
-		return countries.Unknown, fmt.Errorf("not a UUID v8: version %d", version)
+	if optionFlags(u)&RegionFlag != 0 {
+		return countries.Unknown, ErrContinentOnly
 	}
 
+	return countries.CountryCode(countryCode), nil
+}
+
+// ExtractRawCode returns the literal value stored in the country field of a
+// UUID v8, without interpreting it through the countries package. Comparing it
+// with what ExtractCountry maps it to helps diagnose encoding mismatches.
+//
+// The result is a uint32 rather than a uint16 because the field is 20 bits
+// wide: the non-country codes of the countries package, such as
+// countries.NonCountryInmarsat, do not fit in 16 bits.
+//
+// Returns an error if the UUID is not version 8.
+func ExtractRawCode(u uuid.UUID) (uint32, error) {
+	if version := Version(u); version != 8 {
+		return 0, versionErrors[version]
+	}
+
+	return rawCode(u), nil
+}
+
+// rawCode reads the country field of u without checking the version.
+func rawCode(u uuid.UUID) uint32 {
 	// Extract country code from bytes 8-10
-	// Account for variant bits in byte 8
-	countryCode := uint32(uuidBytes[8]&0x3f)<<16 | uint32(uuidBytes[9])<<8 | uint32(uuidBytes[10])
+	// Account for variant and flag bits in byte 8
+	return uint32(u[8]&0x0f)<<16 | uint32(u[9])<<8 | uint32(u[10])
+}
 
-	return countries.CountryCode(countryCode), nil
+// IsUnknownCountry reports whether u is a UUID v8 embedding
+// countries.Unknown, as generated by CountryUUIDv8(countries.Unknown).
+//
+// A UUID v8 with an unknown country is not the same as a missing UUID: uuid.Nil
+// and other non-v8 UUIDs yield an error instead of true.
+//
+// Returns an error if the UUID is not version 8.
+func IsUnknownCountry(u uuid.UUID) (bool, error) {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return false, err
+	}
+
+	return country == countries.Unknown, nil
+}
+
+// MustExtractCountry is like ExtractCountry but panics if the UUID is not
+// version 8. It is intended for UUIDs known to be valid, such as ones generated
+// in the same function or test fixtures.
+func MustExtractCountry(u uuid.UUID) countries.CountryCode {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		panic(`uuidv8country: ExtractCountry(` + u.String() + `): ` + err.Error())
+	}
+	return country
+}
+
+// ExtractNumericCode extracts the ISO 3166-1 numeric code of the country
+// embedded in a UUID v8 generated by CountryUUIDv8, such as 840 for the United
+// States or 643 for Russia.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.USA)
+//	code, err := ExtractNumericCode(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("%03d\n", code) // Output: 840
+//
+// Returns an error if the UUID is not version 8, or wrapping ErrUnknownCountry
+// if the embedded code has no ISO numeric equivalent. That covers
+// countries.Unknown as well as the codes the countries package assigns outside
+// the ISO range 001-899, such as Kosovo (900) and its non-country codes.
+func ExtractNumericCode(u uuid.UUID) (int, error) {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return 0, err
+	}
+
+	code := int(country)
+	if code < 1 || code > 899 || !country.IsValid() {
+		return 0, fmt.Errorf("%w: no ISO 3166-1 numeric code for %d", ErrUnknownCountry, code)
+	}
+
+	return code, nil
+}
+
+// ExtractNumericString is like ExtractNumericCode but returns the code as the
+// fixed-width, zero-padded three-digit string ISO 3166-1 defines, such as
+// "840" for the United States or "004" for Afghanistan.
+//
+// countries.Unknown is not reported as "000", which is not an ISO code, but
+// fails like every other code without an ISO numeric equivalent.
+//
+// Returns an error if the UUID is not version 8, or wrapping ErrUnknownCountry
+// if the embedded code has no ISO numeric equivalent.
+func ExtractNumericString(u uuid.UUID) (string, error) {
+	code, err := ExtractNumericCode(u)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%03d", code), nil
+}
+
+// ExtractCountryAlpha2 extracts the country from a UUID v8 generated by
+// CountryUUIDv8 and returns its ISO 3166-1 alpha-2 code.
+//
+// Countries without an alpha-2 code, including countries.Unknown, are reported
+// as UnknownAlpha2 rather than as an empty string.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Russia)
+//	code, err := ExtractCountryAlpha2(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(code) // Output: RU
+//
+// Returns an error if the UUID is not version 8.
+func ExtractCountryAlpha2(u uuid.UUID) (string, error) {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return "", err
+	}
+
+	code := country.Alpha2()
+	if len(code) != 2 {
+		return UnknownAlpha2, nil
+	}
+
+	return code, nil
+}
+
+// ExtractRegionSubtag returns the BCP 47 region subtag for the embedded
+// country: its ISO 3166-1 alpha-2 code, always in upper case as BCP 47
+// recommends, ready to combine with a language subtag into a locale such as
+// "en-US".
+//
+// Unlike ExtractCountryAlpha2 it does not fall back to UnknownAlpha2, which
+// would form a private-use locale, but fails for countries without an alpha-2
+// code.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Canada)
+//	region, err := ExtractRegionSubtag(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println("fr-" + region) // Output: fr-CA
+//
+// Returns an error if the UUID is not version 8, or wrapping ErrUnknownCountry
+// if the embedded country has no alpha-2 code.
+func ExtractRegionSubtag(u uuid.UUID) (string, error) {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return "", err
+	}
+
+	code := country.Alpha2()
+	if len(code) != 2 {
+		return "", fmt.Errorf("%w: no region subtag for code %d", ErrUnknownCountry, int64(country))
+	}
+
+	return strings.ToUpper(code), nil
+}
+
+// ExtractFlagEmoji returns the flag emoji of the embedded country, the pair of
+// Unicode regional indicator symbols for its alpha-2 code, such as "🇺🇸" for the
+// United States.
+//
+// Countries without an alpha-2 code, including countries.Unknown, yield an
+// empty string rather than an error, so callers can render the result
+// unconditionally.
+//
+// Returns an error if the UUID is not version 8.
+func ExtractFlagEmoji(u uuid.UUID) (string, error) {
+	code, err := ExtractCountryAlpha2(u)
+	if err != nil {
+		return "", err
+	}
+
+	if code == UnknownAlpha2 {
+		return "", nil
+	}
+
+	return MustExtractCountry(u).Emoji(), nil
+}
+
+// ExtractCountryName extracts the country from a UUID v8 generated by
+// CountryUUIDv8 and returns its English name as reported by the countries
+// package, for example "Germany" or "United States".
+//
+// UUIDs embedding countries.Unknown, or any code the countries package does not
+// recognize, yield "Unknown".
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Germany)
+//	name, err := ExtractCountryName(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(name) // Output: Germany
+//
+// Returns an error if the UUID is not version 8.
+func ExtractCountryName(u uuid.UUID) (string, error) {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return "", err
+	}
+
+	return country.String(), nil
+}
+
+// ExtractContinent extracts the country from a UUID v8 generated by
+// CountryUUIDv8 and returns the UN M.49 region (continent) it belongs to.
+//
+// UUIDs embedding countries.Unknown, or any code the countries package does not
+// recognize, yield countries.RegionUnknown. Continent-only UUIDs generated by
+// ContinentUUIDv8 yield their embedded region, just like ExtractRegion.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Japan)
+//	region, err := ExtractContinent(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(region) // Output: Asia
+//
+// Returns an error if the UUID is not version 8.
+func ExtractContinent(u uuid.UUID) (countries.RegionCode, error) {
+	return ExtractRegion(u)
+}
+
+// ExtractCallingCode extracts the country from a UUID v8 generated by
+// CountryUUIDv8 and returns its international calling code, without the
+// leading "+".
+//
+// Calling codes are not unique: the United States, Canada and the other
+// members of the North American Numbering Plan all share 1, and Russia and
+// Kazakhstan share 7, so the result cannot be mapped back to a single country.
+// For countries with several prefixes the countries package lists, the first
+// one is returned, which for some NANP members already includes the area code
+// (1809 for the Dominican Republic).
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Germany)
+//	code, err := ExtractCallingCode(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("+%d\n", code) // Output: +49
+//
+// Returns an error if the UUID is not version 8, or wrapping ErrUnknownCountry
+// if the embedded country has no calling code.
+func ExtractCallingCode(u uuid.UUID) (int, error) {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return 0, err
+	}
+
+	codes := country.CallCodes()
+	if len(codes) == 0 || codes[0] <= 0 {
+		return 0, fmt.Errorf("%w: no calling code for %d", ErrUnknownCountry, int(country))
+	}
+
+	return int(codes[0]), nil
+}
+
+// ExtractCurrency extracts the country from a UUID v8 generated by
+// CountryUUIDv8 and returns the ISO 4217 alphabetic code of its currency, such
+// as "EUR" for Germany.
+//
+// Only one currency is returned per country, the one the countries package
+// lists. Several countries officially use more than one, for example Panama
+// (PAB and USD), Bhutan (BTN and INR) or Cuba, so treat the result as a default
+// rather than the only currency accepted there.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Japan)
+//	currency, err := ExtractCurrency(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(currency) // Output: JPY
+//
+// Returns an error if the UUID is not version 8, or wrapping ErrUnknownCountry
+// if the embedded country has no currency, as is the case for
+// countries.Unknown, Antarctica and the non-country codes.
+func ExtractCurrency(u uuid.UUID) (string, error) {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return "", err
+	}
+
+	// The countries package reports a missing currency as "Unknown" or "None"
+	currency := country.Currency().Alpha()
+	if len(currency) != 3 {
+		return "", fmt.Errorf("%w: no currency for %d", ErrUnknownCountry, int(country))
+	}
+
+	return currency, nil
+}
+
+// ExtractNodeID extracts the node id stored by the WithNodeID option.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Japan, WithNodeID(7))
+//	node, err := ExtractNodeID(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(node) // Output: 7
+//
+// Returns an error if the UUID is not version 8, or ErrNoNodeID if it was
+// generated without WithNodeID, in which case the corresponding byte holds
+// random data.
+func ExtractNodeID(u uuid.UUID) (uint8, error) {
+	if err := checkVersionVariant(u); err != nil {
+		return 0, err
+	}
+
+	if optionFlags(u)&NodeIDFlag == 0 {
+		return 0, ErrNoNodeID
+	}
+
+	return u[NodeIDByteOffset], nil
 }
 
 // GetTimestamp extracts the timestamp from a UUID generated by CountryUUIDv8.
 //
-// The timestamp is stored in the first 8 bytes of the UUID as a Unix timestamp
-// in nanoseconds (big-endian format).
+// The timestamp is stored in the first 6 bytes of the UUID as a Unix timestamp
+// in milliseconds (big-endian format), so the result has millisecond precision.
+// It is always in UTC, regardless of the local time zone of either the
+// generating or the decoding machine.
 //
 // Example:
 //
@@ -113,8 +672,111 @@ func ExtractCountry(u uuid.UUID) (countries.CountryCode, error) {
 //
 // This function does not validate the UUID version, so it can be called on any UUID,
 // though it will only return meaningful results for UUIDs generated by CountryUUIDv8.
+// UUIDs from a Generator created WithEpoch must be decoded with its
+// GetTimestamp method instead.
 func GetTimestamp(u uuid.UUID) time.Time {
-	uuidBytes := u[:]
-	timestamp := binary.BigEndian.Uint64(uuidBytes[0:8])
-	return time.Unix(0, int64(timestamp))
+	return timestampAt(u, 0)
+}
+
+// GetTimestampNanos extracts the timestamp from a UUID generated by
+// CountryUUIDv8 including its sub-millisecond fraction.
+//
+// The 12 bits following the version nibble store the fraction of the
+// millisecond in steps of 1/4096, so the effective resolution is about 244ns.
+// The result never exceeds the original creation time. For UUIDs from a
+// monotonic generator the fraction also absorbs the counter, so it may run
+// slightly ahead of the wall clock.
+//
+// Example:
+//
+//	u, _ := CountryUUIDv8(countries.Japan)
+//	timestamp := GetTimestampNanos(u)
+//	fmt.Println(timestamp.Format(time.RFC3339Nano))
+//
+// Like GetTimestamp, the result is in UTC and this function does not validate
+// the UUID version.
+func GetTimestampNanos(u uuid.UUID) time.Time {
+	return timestampNanosAt(u, 0)
+}
+
+// ExtractTimestamp is like GetTimestamp but first checks that u is a UUID v8
+// with the RFC 4122 variant, so a foreign UUID is not silently misinterpreted
+// as a timestamp. Like GetTimestamp and Decode it has millisecond precision;
+// use GetTimestampNanos for the sub-millisecond fraction, and GetTimestamp for
+// inputs already known to be valid.
+//
+// Example:
+//
+//	created, err := ExtractTimestamp(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(created.Format(time.RFC3339))
+//
+// Returns an error wrapping ErrWrongVersion or ErrWrongVariant otherwise.
+func ExtractTimestamp(u uuid.UUID) (time.Time, error) {
+	if err := checkVersionVariant(u); err != nil {
+		return time.Time{}, err
+	}
+
+	return GetTimestamp(u), nil
+}
+
+// Age returns how long ago u was created, the time elapsed since its
+// millisecond timestamp as GetTimestamp returns it, for "record age" displays.
+// It is AgeAt with the current time.
+//
+// Example:
+//
+//	age, err := Age(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("created %v ago\n", age.Round(time.Second))
+//
+// Returns an error wrapping ErrWrongVersion or ErrWrongVariant if u is not a
+// UUID v8 with the RFC 4122 variant.
+func Age(u uuid.UUID) (time.Duration, error) {
+	return AgeAt(u, time.Now())
+}
+
+// AgeAt is like Age but measures the age at ref instead of now, which makes it
+// deterministic in tests. The result is negative if u was created after ref,
+// for example when the clocks of the generating and the measuring machines
+// disagree.
+//
+// Returns an error wrapping ErrWrongVersion or ErrWrongVariant if u is not a
+// UUID v8 with the RFC 4122 variant.
+func AgeAt(u uuid.UUID, ref time.Time) (time.Duration, error) {
+	if err := checkVersionVariant(u); err != nil {
+		return 0, err
+	}
+
+	return ref.Sub(GetTimestamp(u)), nil
+}
+
+// Decode extracts both the country and the timestamp of u, validating the
+// version and variant only once. The timestamp is the same as GetTimestamp
+// returns, in milliseconds and UTC.
+//
+// Example:
+//
+//	country, created, err := Decode(u)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(country, created)
+//
+// Returns an error if u is not version 8 with the RFC 4122 variant, or
+// ErrContinentOnly if it was generated by ContinentUUIDv8.
+func Decode(u uuid.UUID) (countries.CountryCode, time.Time, error) {
+	if err := checkVersionVariant(u); err != nil {
+		return countries.Unknown, time.Time{}, err
+	}
+
+	if optionFlags(u)&RegionFlag != 0 {
+		return countries.Unknown, time.Time{}, ErrContinentOnly
+	}
+
+	return countries.CountryCode(rawCode(u)), GetTimestamp(u), nil
 }
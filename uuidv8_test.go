@@ -1,6 +1,8 @@
 package uuidv8country
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 	"time"
 
@@ -75,64 +77,696 @@ func TestCountryUUIDv8_Uniqueness(t *testing.T) {
 	}
 }
 
+func TestCountryUUIDv8_UniquenessStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping 1M-UUID stress test in short mode")
+	}
+
+	// A million UUIDs for one country share everything but the timestamp and
+	// the 40 random bits, so any collision points at too little entropy
+	const count = 1000000
+	seen := make(map[uuid.UUID]struct{}, count)
+
+	for i := 0; i < count; i++ {
+		u, err := CountryUUIDv8(countries.Russia)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+		if _, dup := seen[u]; dup {
+			t.Fatalf("Found duplicate UUID after %d generations: %s", i, u)
+		}
+		seen[u] = struct{}{}
+	}
+}
+
+func TestCountryUUIDv8FromAlpha2(t *testing.T) {
+	tests := []struct {
+		code    string
+		country countries.CountryCode
+	}{
+		{"US", countries.USA},
+		{"de", countries.Germany},
+		{" ru ", countries.Russia},
+		{"Jp", countries.Japan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			u, err := CountryUUIDv8FromAlpha2(tt.code)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8FromAlpha2() error = %v", err)
+			}
+
+			if version := (u[6] & 0xf0) >> 4; version != 8 {
+				t.Errorf("UUID version = %d, expected 8", version)
+			}
+			if variant := (u[8] & 0xc0) >> 6; variant != 2 {
+				t.Errorf("UUID variant = %02b, expected 10", variant)
+			}
+
+			extractedCountry, err := ExtractCountry(u)
+			if err != nil {
+				t.Fatalf("ExtractCountry() error = %v", err)
+			}
+			if extractedCountry != tt.country {
+				t.Errorf("ExtractCountry() = %v, expected %v", extractedCountry, tt.country)
+			}
+		})
+	}
+}
+
+func TestCountryUUIDv8FromAlpha2_Invalid(t *testing.T) {
+	for _, code := range []string{"", "   ", "XX", "ZZ", "USA", "Germany"} {
+		if _, err := CountryUUIDv8FromAlpha2(code); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("CountryUUIDv8FromAlpha2(%q) error = %v, expected ErrUnknownCountry", code, err)
+		}
+	}
+}
+
+func TestCountryUUIDv8FromAlpha3(t *testing.T) {
+	tests := []struct {
+		code    string
+		country countries.CountryCode
+	}{
+		{"USA", countries.USA},
+		{"usa", countries.USA},
+		{"DEU", countries.Germany},
+		{" rus ", countries.Russia},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			u, err := CountryUUIDv8FromAlpha3(tt.code)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8FromAlpha3() error = %v", err)
+			}
+
+			extractedCountry, err := ExtractCountry(u)
+			if err != nil {
+				t.Fatalf("ExtractCountry() error = %v", err)
+			}
+			if extractedCountry != tt.country {
+				t.Errorf("ExtractCountry() = %v, expected %v", extractedCountry, tt.country)
+			}
+		})
+	}
+}
+
+func TestCountryUUIDv8FromAlpha3_Invalid(t *testing.T) {
+	for _, code := range []string{"", "XXX", "US", "Germany"} {
+		if _, err := CountryUUIDv8FromAlpha3(code); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("CountryUUIDv8FromAlpha3(%q) error = %v, expected ErrUnknownCountry", code, err)
+		}
+	}
+}
+
+func TestCountryUUIDv8FromName(t *testing.T) {
+	tests := []struct {
+		name    string
+		country countries.CountryCode
+	}{
+		{"Germany", countries.Germany},
+		{"germany", countries.Germany},
+		{" FRANCE ", countries.France},
+		{"Russia", countries.Russia},
+		{"United States", countries.USA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := CountryUUIDv8FromName(tt.name)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8FromName() error = %v", err)
+			}
+
+			if country := MustExtractCountry(u); country != tt.country {
+				t.Errorf("ExtractCountry() = %v, expected %v", country, tt.country)
+			}
+
+			// The canonical spelling round-trips
+			name, _ := ExtractCountryName(u)
+			again, err := CountryUUIDv8FromName(name)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8FromName(%q) error = %v", name, err)
+			}
+			if country := MustExtractCountry(again); country != tt.country {
+				t.Errorf("ExtractCountry() = %v, expected %v", country, tt.country)
+			}
+		})
+	}
+}
+
+func TestCountryUUIDv8FromName_Invalid(t *testing.T) {
+	for _, name := range []string{"", "Atlantis", "Czechoslovakia", "XX"} {
+		if _, err := CountryUUIDv8FromName(name); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("CountryUUIDv8FromName(%q) error = %v, expected ErrUnknownCountry", name, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	u, err := CountryUUIDv8(countries.Canada)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+
+	parsed, err := Parse(u.String())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed != u {
+		t.Errorf("Parse() = %s, expected %s", parsed, u)
+	}
+
+	if got := MustParse(u.String()); got != u {
+		t.Errorf("MustParse() = %s, expected %s", got, u)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error // nil for syntax errors, which have no sentinel
+	}{
+		{"Empty", "", nil},
+		{"Malformed", "not-a-uuid", nil},
+		{"Version4", uuid.New().String(), ErrWrongVersion},
+		{"WrongVariant", "018d1234-5678-8abc-cdef-0123456789ab", ErrWrongVariant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if err == nil {
+				t.Fatalf("Parse(%q) should return error", tt.input)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Parse(%q) error = %v, expected %v", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParse() should panic for non-v8 UUID")
+		}
+	}()
+
+	MustParse(uuid.New().String())
+}
+
 func TestExtractCountry_Success(t *testing.T) {
 	tests := []struct {
-		name    string
+		name    string
+		country countries.CountryCode
+	}{
+		{"Russia", countries.Russia},
+		{"USA", countries.USA},
+		{"Germany", countries.Germany},
+		{"Ukraine", countries.Ukraine},
+		{"Japan", countries.Japan},
+		{"Brazil", countries.Brazil},
+		{"China", countries.China},
+		{"Unknown", countries.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Generate UUID
+			u, err := CountryUUIDv8(tt.country)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+
+			// Extract country back
+			extractedCountry, err := ExtractCountry(u)
+			if err != nil {
+				t.Fatalf("ExtractCountry() error = %v", err)
+			}
+
+			// Check match
+			if extractedCountry != tt.country {
+				t.Errorf("ExtractCountry() = %v (code: %d), expected %v (code: %d)",
+					extractedCountry, extractedCountry, tt.country, tt.country)
+			}
+		})
+	}
+}
+
+func TestExtractCountry_WrongVersion(t *testing.T) {
+	// Create UUID v4 (not v8)
+	u := uuid.New()
+
+	_, err := ExtractCountry(u)
+	if err == nil {
+		t.Error("ExtractCountry() should return error for non-v8 UUID")
+	}
+	if got, expected := err.Error(), "not a UUID v8: version 4"; got != expected {
+		t.Errorf("ExtractCountry() error = %q, expected %q", got, expected)
+	}
+	if !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCountry() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractCountry_Allocs(t *testing.T) {
+	valid, _ := CountryUUIDv8(countries.Russia)
+	foreign := uuid.New()
+
+	for name, u := range map[string]uuid.UUID{"v8": valid, "v4": foreign} {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = ExtractCountry(u)
+		})
+		if allocs != 0 {
+			t.Errorf("ExtractCountry(%s) allocs = %v, expected 0", name, allocs)
+		}
+	}
+}
+
+func TestExtractRawCode(t *testing.T) {
+	codes := []countries.CountryCode{
+		countries.Unknown,
+		countries.USA,
+		countries.Kosovo,
+		countries.NonCountryInmarsat,
+		countries.CountryCode(12345),
+		countries.CountryCode(RegionCodeBase - 1),
+	}
+
+	for _, code := range codes {
+		u, _ := CountryUUIDv8(code, WithNodeID(0xff))
+
+		raw, err := ExtractRawCode(u)
+		if err != nil {
+			t.Fatalf("ExtractRawCode() error = %v", err)
+		}
+		if raw != uint32(code) {
+			t.Errorf("ExtractRawCode() = %d, expected %d", raw, code)
+		}
+	}
+
+	if _, err := ExtractRawCode(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractRawCode() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestIsUnknownCountry(t *testing.T) {
+	u, err := CountryUUIDv8(countries.Unknown)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8() error = %v", err)
+	}
+	if u == uuid.Nil {
+		t.Fatal("CountryUUIDv8(countries.Unknown) = uuid.Nil")
+	}
+	if err := Validate(u); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("Validate() error = %v, expected ErrUnknownCountry", err)
+	}
+	if country := MustExtractCountry(u); country != countries.Unknown {
+		t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Unknown)
+	}
+
+	unknown, err := IsUnknownCountry(u)
+	if err != nil || !unknown {
+		t.Errorf("IsUnknownCountry() = %v, %v, expected true", unknown, err)
+	}
+
+	known, _ := CountryUUIDv8(countries.Spain)
+	if unknown, err := IsUnknownCountry(known); err != nil || unknown {
+		t.Errorf("IsUnknownCountry() = %v, %v, expected false", unknown, err)
+	}
+
+	if _, err := IsUnknownCountry(uuid.Nil); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("IsUnknownCountry(uuid.Nil) error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestMustExtractCountry(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Greece)
+	if got := MustExtractCountry(u); got != countries.Greece {
+		t.Errorf("MustExtractCountry() = %v, expected %v", got, countries.Greece)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustExtractCountry() should panic for non-v8 UUID")
+		}
+	}()
+	MustExtractCountry(uuid.New())
+}
+
+func TestExtractNumericCode(t *testing.T) {
+	// ISO 3166-1 numeric codes, independent of the countries package
+	tests := []struct {
+		alpha2 string
+		want   int
+	}{
+		{"US", 840},
+		{"RU", 643},
+		{"DE", 276},
+		{"JP", 392},
+		{"AF", 4},
+		{"ZW", 716},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alpha2, func(t *testing.T) {
+			u, err := CountryUUIDv8FromAlpha2(tt.alpha2)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8FromAlpha2() error = %v", err)
+			}
+
+			code, err := ExtractNumericCode(u)
+			if err != nil {
+				t.Fatalf("ExtractNumericCode() error = %v", err)
+			}
+			if code != tt.want {
+				t.Errorf("ExtractNumericCode() = %d, expected %d", code, tt.want)
+			}
+
+			// The numeric code maps back to the same country
+			country := MustExtractCountry(u)
+			if countries.ByNumeric(code) != country {
+				t.Errorf("ByNumeric(%d) = %v, expected %v", code, countries.ByNumeric(code), country)
+			}
+		})
+	}
+}
+
+func TestExtractNumericCode_NoISOCode(t *testing.T) {
+	for _, country := range []countries.CountryCode{countries.Unknown, countries.Kosovo, countries.International} {
+		u, _ := CountryUUIDv8(country)
+		if _, err := ExtractNumericCode(u); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("ExtractNumericCode(%v) error = %v, expected ErrUnknownCountry", country, err)
+		}
+	}
+
+	if _, err := ExtractNumericCode(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractNumericCode() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractNumericString(t *testing.T) {
+	tests := []struct {
+		alpha2 string
+		want   string
+	}{
+		{"US", "840"},
+		{"AF", "004"},
+		{"AU", "036"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alpha2, func(t *testing.T) {
+			u, _ := CountryUUIDv8FromAlpha2(tt.alpha2)
+
+			code, err := ExtractNumericString(u)
+			if err != nil {
+				t.Fatalf("ExtractNumericString() error = %v", err)
+			}
+			if code != tt.want {
+				t.Errorf("ExtractNumericString() = %q, expected %q", code, tt.want)
+			}
+		})
+	}
+
+	unknown, _ := CountryUUIDv8(countries.Unknown)
+	if _, err := ExtractNumericString(unknown); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("ExtractNumericString() error = %v, expected ErrUnknownCountry", err)
+	}
+	if _, err := ExtractNumericString(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractNumericString() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractCountryAlpha2(t *testing.T) {
+	tests := []struct {
+		country countries.CountryCode
+		want    string
+	}{
+		{countries.Russia, "RU"},
+		{countries.USA, "US"},
+		{countries.Germany, "DE"},
+		{countries.Unknown, UnknownAlpha2},
+		{countries.None, UnknownAlpha2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			u, err := CountryUUIDv8(tt.country)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+
+			code, err := ExtractCountryAlpha2(u)
+			if err != nil {
+				t.Fatalf("ExtractCountryAlpha2() error = %v", err)
+			}
+			if code != tt.want {
+				t.Errorf("ExtractCountryAlpha2() = %q, expected %q", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCountryAlpha2_WrongVersion(t *testing.T) {
+	if _, err := ExtractCountryAlpha2(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCountryAlpha2() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractRegionSubtag(t *testing.T) {
+	tests := []struct {
+		country countries.CountryCode
+		want    string
+	}{
+		{countries.USA, "US"},
+		{countries.Canada, "CA"},
+		{countries.Kosovo, "XK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			u, _ := CountryUUIDv8(tt.country)
+
+			region, err := ExtractRegionSubtag(u)
+			if err != nil {
+				t.Fatalf("ExtractRegionSubtag() error = %v", err)
+			}
+			if region != tt.want {
+				t.Errorf("ExtractRegionSubtag() = %q, expected %q", region, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractRegionSubtag_Errors(t *testing.T) {
+	for _, country := range []countries.CountryCode{countries.Unknown, countries.None} {
+		u, _ := CountryUUIDv8(country)
+		if _, err := ExtractRegionSubtag(u); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("ExtractRegionSubtag(%d) error = %v, expected ErrUnknownCountry", int64(country), err)
+		}
+	}
+
+	if _, err := ExtractRegionSubtag(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractRegionSubtag() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractFlagEmoji(t *testing.T) {
+	tests := []struct {
+		country countries.CountryCode
+		want    string
+	}{
+		{countries.USA, "\U0001F1FA\U0001F1F8"},
+		{countries.Japan, "\U0001F1EF\U0001F1F5"},
+		{countries.Unknown, ""},
+		{countries.None, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country.String(), func(t *testing.T) {
+			u, _ := CountryUUIDv8(tt.country)
+
+			flag, err := ExtractFlagEmoji(u)
+			if err != nil {
+				t.Fatalf("ExtractFlagEmoji() error = %v", err)
+			}
+			if flag != tt.want {
+				t.Errorf("ExtractFlagEmoji() = %q, expected %q", flag, tt.want)
+			}
+		})
+	}
+
+	if _, err := ExtractFlagEmoji(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractFlagEmoji() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractCountryName(t *testing.T) {
+	tests := []struct {
+		country countries.CountryCode
+		want    string
+	}{
+		{countries.Russia, "Russian Federation"},
+		{countries.USA, "United States"},
+		{countries.Germany, "Germany"},
+		{countries.Unknown, countries.UnknownMsg},
+		{countries.CountryCode(1), countries.UnknownMsg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			u, err := CountryUUIDv8(tt.country)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+
+			name, err := ExtractCountryName(u)
+			if err != nil {
+				t.Fatalf("ExtractCountryName() error = %v", err)
+			}
+			if name != tt.want {
+				t.Errorf("ExtractCountryName() = %q, expected %q", name, tt.want)
+			}
+		})
+	}
+
+	if _, err := ExtractCountryName(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCountryName() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractContinent(t *testing.T) {
+	tests := []struct {
+		country countries.CountryCode
+		want    countries.RegionCode
+	}{
+		{countries.Japan, countries.RegionAS},
+		{countries.Germany, countries.RegionEU},
+		{countries.Brazil, countries.RegionSA},
+		{countries.Canada, countries.RegionNA},
+		{countries.Nigeria, countries.RegionAF},
+		{countries.Australia, countries.RegionOC},
+		{countries.Unknown, countries.RegionUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country.String(), func(t *testing.T) {
+			u, err := CountryUUIDv8(tt.country)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() error = %v", err)
+			}
+
+			region, err := ExtractContinent(u)
+			if err != nil {
+				t.Fatalf("ExtractContinent() error = %v", err)
+			}
+			if region != tt.want {
+				t.Errorf("ExtractContinent() = %v, expected %v", region, tt.want)
+			}
+		})
+	}
+
+	if _, err := ExtractContinent(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractContinent() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractCallingCode(t *testing.T) {
+	tests := []struct {
+		country countries.CountryCode
+		want    int
+	}{
+		{countries.USA, 1},
+		{countries.Canada, 1},
+		{countries.Russia, 7},
+		{countries.Kazakhstan, 7},
+		{countries.Germany, 49},
+		{countries.Japan, 81},
+		{countries.Kosovo, 383},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country.String(), func(t *testing.T) {
+			u, _ := CountryUUIDv8(tt.country)
+
+			code, err := ExtractCallingCode(u)
+			if err != nil {
+				t.Fatalf("ExtractCallingCode() error = %v", err)
+			}
+			if code != tt.want {
+				t.Errorf("ExtractCallingCode() = %d, expected %d", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCallingCode_Errors(t *testing.T) {
+	for _, country := range []countries.CountryCode{countries.Unknown, countries.None} {
+		u, _ := CountryUUIDv8(country)
+		if _, err := ExtractCallingCode(u); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("ExtractCallingCode(%d) error = %v, expected ErrUnknownCountry", country, err)
+		}
+	}
+
+	if _, err := ExtractCallingCode(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCallingCode() error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestExtractCurrency(t *testing.T) {
+	tests := []struct {
 		country countries.CountryCode
+		want    string
 	}{
-		{"Russia", countries.Russia},
-		{"USA", countries.USA},
-		{"Germany", countries.Germany},
-		{"Ukraine", countries.Ukraine},
-		{"Japan", countries.Japan},
-		{"Brazil", countries.Brazil},
-		{"China", countries.China},
-		{"Unknown", countries.Unknown},
+		{countries.USA, "USD"},
+		{countries.Germany, "EUR"},
+		{countries.Japan, "JPY"},
+		{countries.Switzerland, "CHF"},
+		{countries.Kosovo, "EUR"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Generate UUID
-			u, err := CountryUUIDv8(tt.country)
-			if err != nil {
-				t.Fatalf("CountryUUIDv8() error = %v", err)
-			}
+		t.Run(tt.country.String(), func(t *testing.T) {
+			u, _ := CountryUUIDv8(tt.country)
 
-			// Extract country back
-			extractedCountry, err := ExtractCountry(u)
+			currency, err := ExtractCurrency(u)
 			if err != nil {
-				t.Fatalf("ExtractCountry() error = %v", err)
+				t.Fatalf("ExtractCurrency() error = %v", err)
 			}
-
-			// Check match
-			if extractedCountry != tt.country {
-				t.Errorf("ExtractCountry() = %v (code: %d), expected %v (code: %d)",
-					extractedCountry, extractedCountry, tt.country, tt.country)
+			if currency != tt.want {
+				t.Errorf("ExtractCurrency() = %q, expected %q", currency, tt.want)
 			}
 		})
 	}
 }
 
-func TestExtractCountry_WrongVersion(t *testing.T) {
-	// Create UUID v4 (not v8)
-	u := uuid.New()
+func TestExtractCurrency_Errors(t *testing.T) {
+	for _, country := range []countries.CountryCode{countries.Unknown, countries.None, countries.Antarctica, countries.CountryCode(1)} {
+		u, _ := CountryUUIDv8(country)
+		if _, err := ExtractCurrency(u); !errors.Is(err, ErrUnknownCountry) {
+			t.Errorf("ExtractCurrency(%d) error = %v, expected ErrUnknownCountry", country, err)
+		}
+	}
 
-	_, err := ExtractCountry(u)
-	if err == nil {
-		t.Error("ExtractCountry() should return error for non-v8 UUID")
+	if _, err := ExtractCurrency(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractCurrency() error = %v, expected ErrWrongVersion", err)
 	}
 }
 
 func TestGetTimestamp(t *testing.T) {
-	beforeTime := time.Now()
-	time.Sleep(1 * time.Millisecond)
+	// The timestamp is stored with millisecond precision, so compare against
+	// the truncated start time rather than sleeping past it
+	beforeTime := time.Now().Truncate(time.Millisecond)
 
 	u, err := CountryUUIDv8(countries.Russia)
 	if err != nil {
 		t.Fatalf("CountryUUIDv8() error = %v", err)
 	}
 
-	time.Sleep(1 * time.Millisecond)
 	afterTime := time.Now()
 
 	extractedTime := GetTimestamp(u)
@@ -147,6 +781,366 @@ func TestGetTimestamp(t *testing.T) {
 	}
 }
 
+func TestExtractTimestamp(t *testing.T) {
+	created := time.Date(2025, 7, 4, 12, 0, 0, 500000, time.UTC)
+	u, _ := CountryUUIDv8At(countries.Malta, created)
+
+	ts, err := ExtractTimestamp(u)
+	if err != nil {
+		t.Fatalf("ExtractTimestamp() error = %v", err)
+	}
+	if !ts.Equal(GetTimestamp(u)) {
+		t.Errorf("ExtractTimestamp() = %v, expected %v", ts, GetTimestamp(u))
+	}
+	if want := created.Truncate(time.Millisecond); !ts.Equal(want) {
+		t.Errorf("ExtractTimestamp() = %v, expected %v", ts, want)
+	}
+	if _, decoded, _ := Decode(u); !ts.Equal(decoded) {
+		t.Errorf("ExtractTimestamp() = %v, Decode() = %v, expected the same time", ts, decoded)
+	}
+
+	if _, err := ExtractTimestamp(uuid.New()); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("ExtractTimestamp() error = %v, expected ErrWrongVersion", err)
+	}
+
+	u[8] &= 0x3f
+	if _, err := ExtractTimestamp(u); !errors.Is(err, ErrWrongVariant) {
+		t.Errorf("ExtractTimestamp() error = %v, expected ErrWrongVariant", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	created := time.Date(2025, 7, 4, 12, 0, 0, 500000, time.UTC)
+	u, _ := CountryUUIDv8At(countries.Malta, created, WithChecksum())
+
+	country, ts, err := Decode(u)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if country != countries.Malta {
+		t.Errorf("Decode() country = %v, expected %v", country, countries.Malta)
+	}
+	if !ts.Equal(GetTimestamp(u)) {
+		t.Errorf("Decode() timestamp = %v, expected %v", ts, GetTimestamp(u))
+	}
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want error
+	}{
+		{"Version4", uuid.New(), ErrWrongVersion},
+		{"WrongVariant", func() uuid.UUID { v := u; v[8] &= 0x3f; return v }(), ErrWrongVariant},
+		{"ContinentOnly", func() uuid.UUID { v, _ := ContinentUUIDv8(countries.RegionEU); return v }(), ErrContinentOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Decode(tt.u); !errors.Is(err, tt.want) {
+				t.Errorf("Decode() error = %v, expected %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimestamp_UTC(t *testing.T) {
+	local := time.Date(2024, 2, 29, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+
+	u, err := CountryUUIDv8At(countries.Brazil, local)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8At() error = %v", err)
+	}
+
+	for name, ts := range map[string]time.Time{"GetTimestamp": GetTimestamp(u), "GetTimestampNanos": GetTimestampNanos(u)} {
+		if ts.Location() != time.UTC {
+			t.Errorf("%s().Location() = %v, expected UTC", name, ts.Location())
+		}
+	}
+
+	// Same instant, different zone: same UUID timestamp
+	utc, _ := CountryUUIDv8At(countries.Brazil, local.UTC())
+	if tickFrom(u) != tickFrom(utc) {
+		t.Errorf("timestamps differ for the same instant: %v and %v", GetTimestamp(u), GetTimestamp(utc))
+	}
+
+	expected := time.Date(2024, 3, 1, 4, 30, 0, 0, time.UTC)
+	if got := GetTimestamp(u); got != expected {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, expected)
+	}
+}
+
+func TestCountryUUIDv8At(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+	}{
+		{"Epoch", time.Unix(0, 0)},
+		{"Historical", time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{"SubMillisecond", time.Date(2020, 3, 1, 12, 0, 0, 123456789, time.UTC)},
+		{"NonUTC", time.Date(2021, 6, 15, 8, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := CountryUUIDv8At(countries.Brazil, tt.time)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8At() error = %v", err)
+			}
+
+			want := tt.time.Truncate(time.Millisecond)
+			if got := GetTimestamp(u); !got.Equal(want) {
+				t.Errorf("GetTimestamp() = %v, expected %v", got, want)
+			}
+
+			country, err := ExtractCountry(u)
+			if err != nil {
+				t.Fatalf("ExtractCountry() error = %v", err)
+			}
+			if country != countries.Brazil {
+				t.Errorf("ExtractCountry() = %v, expected %v", country, countries.Brazil)
+			}
+		})
+	}
+}
+
+func TestCountryUUIDv8At_BeforeEpoch(t *testing.T) {
+	_, err := CountryUUIDv8At(countries.Brazil, time.Unix(0, 0).Add(-time.Millisecond))
+	if !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("CountryUUIDv8At() error = %v, expected ErrTimestampOutOfRange", err)
+	}
+}
+
+func TestCountryUUIDv8At_MaxTimestamp(t *testing.T) {
+	if want := time.Date(10889, 8, 2, 5, 31, 50, 655000000, time.UTC); !MaxTimestamp.Equal(want) {
+		t.Errorf("MaxTimestamp = %v, expected %v", MaxTimestamp, want)
+	}
+
+	// The last representable instant, including the largest fraction
+	last := MaxTimestamp.Add(time.Millisecond - time.Nanosecond)
+	u, err := CountryUUIDv8At(countries.Brazil, last)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8At() error = %v", err)
+	}
+	if got := GetTimestamp(u); !got.Equal(MaxTimestamp) {
+		t.Errorf("GetTimestamp() = %v, expected %v", got, MaxTimestamp)
+	}
+
+	_, err = CountryUUIDv8At(countries.Brazil, MaxTimestamp.Add(time.Millisecond))
+	if !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("CountryUUIDv8At() error = %v, expected ErrTimestampOutOfRange", err)
+	}
+
+	// The limit moves with the epoch
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithEpoch(epoch))
+	if _, err := g.CountryUUIDv8At(countries.Brazil, MaxTimestamp.Add(time.Millisecond)); err != nil {
+		t.Errorf("CountryUUIDv8At() with epoch error = %v", err)
+	}
+}
+
+func TestCountryUUIDv8_MonotonicAtMaxTimestamp(t *testing.T) {
+	// The clock sits on fraction 4091 of the last millisecond, leaving room for
+	// the counter to reach 4095 in the next four UUIDs
+	clock := &fakeClock{now: MaxTimestamp.Add(999 * time.Microsecond)}
+
+	for _, opt := range []GeneratorOption{WithMonotonic(), WithPerCountryCounter()} {
+		g := NewGenerator(WithClock(clock), opt)
+
+		prev := uuid.Nil
+		for i := 0; i < 5; i++ {
+			u, err := g.CountryUUIDv8(countries.Brazil)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8() #%d error = %v", i, err)
+			}
+			if got := GetTimestamp(u); !got.Equal(MaxTimestamp) {
+				t.Errorf("GetTimestamp() #%d = %v, expected %v", i, got, MaxTimestamp)
+			}
+			if Compare(prev, u) >= 0 {
+				t.Errorf("UUID #%d %s is not after %s", i, u, prev)
+			}
+			prev = u
+		}
+
+		// The counter is exhausted and must not wrap around to the epoch
+		if _, err := g.CountryUUIDv8(countries.Brazil); !errors.Is(err, ErrTimestampOutOfRange) {
+			t.Errorf("CountryUUIDv8() error = %v, expected ErrTimestampOutOfRange", err)
+		}
+		if _, err := g.CountryUUIDv8Batch(countries.Brazil, 2); !errors.Is(err, ErrTimestampOutOfRange) {
+			t.Errorf("CountryUUIDv8Batch() error = %v, expected ErrTimestampOutOfRange", err)
+		}
+	}
+
+	// A batch running into the limit fails as a whole
+	g := NewGenerator(WithClock(clock), WithMonotonic())
+	if _, err := g.CountryUUIDv8Batch(countries.Brazil, 6); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("CountryUUIDv8Batch(6) error = %v, expected ErrTimestampOutOfRange", err)
+	}
+}
+
+func TestCountryUUIDv8Batch(t *testing.T) {
+	const n = 1000
+
+	us, err := CountryUUIDv8Batch(countries.India, n)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8Batch() error = %v", err)
+	}
+
+	if len(us) != n {
+		t.Fatalf("CountryUUIDv8Batch() returned %d UUIDs, expected %d", len(us), n)
+	}
+
+	uuidMap := make(map[uuid.UUID]bool, n)
+	for _, u := range us {
+		if uuidMap[u] {
+			t.Fatalf("Found duplicate UUID in batch: %s", u)
+		}
+		uuidMap[u] = true
+
+		country, err := ExtractCountry(u)
+		if err != nil {
+			t.Fatalf("ExtractCountry() error = %v", err)
+		}
+		if country != countries.India {
+			t.Errorf("ExtractCountry() = %v, expected %v", country, countries.India)
+		}
+
+		if variant := (u[8] & 0xc0) >> 6; variant != 2 {
+			t.Errorf("UUID variant = %02b, expected 10", variant)
+		}
+	}
+}
+
+func TestCountryUUIDv8Batch_Sizes(t *testing.T) {
+	us, err := CountryUUIDv8Batch(countries.India, 0)
+	if err != nil {
+		t.Fatalf("CountryUUIDv8Batch(0) error = %v", err)
+	}
+	if len(us) != 0 {
+		t.Errorf("CountryUUIDv8Batch(0) returned %d UUIDs, expected 0", len(us))
+	}
+
+	if _, err := CountryUUIDv8Batch(countries.India, -1); err == nil {
+		t.Error("CountryUUIDv8Batch(-1) should return error")
+	}
+}
+
+func TestCountryUUIDv8Into(t *testing.T) {
+	dst := make([]uuid.UUID, 64)
+
+	n, err := CountryUUIDv8Into(dst, countries.India, WithNodeID(9))
+	if err != nil {
+		t.Fatalf("CountryUUIDv8Into() error = %v", err)
+	}
+	if n != len(dst) {
+		t.Errorf("CountryUUIDv8Into() = %d, expected %d", n, len(dst))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(dst))
+	for i, u := range dst {
+		if seen[u] {
+			t.Fatalf("Found duplicate UUID in batch: %s", u)
+		}
+		seen[u] = true
+
+		if country := MustExtractCountry(u); country != countries.India {
+			t.Errorf("ExtractCountry(dst[%d]) = %v, expected %v", i, country, countries.India)
+		}
+		if id, _ := ExtractNodeID(u); id != 9 {
+			t.Errorf("ExtractNodeID(dst[%d]) = %d, expected 9", i, id)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = CountryUUIDv8Into(dst, countries.India)
+	})
+	if allocs != 0 {
+		t.Errorf("CountryUUIDv8Into() allocs = %v, expected 0", allocs)
+	}
+}
+
+func TestCountryUUIDv8Into_Errors(t *testing.T) {
+	for _, dst := range [][]uuid.UUID{nil, {}} {
+		if n, err := CountryUUIDv8Into(dst, countries.India); err == nil || n != 0 {
+			t.Errorf("CountryUUIDv8Into(%v) = %d, %v, expected an error", dst, n, err)
+		}
+	}
+
+	dst := make([]uuid.UUID, 2)
+	if n, err := CountryUUIDv8Into(dst, -1); !errors.Is(err, ErrInvalidCountryCode) || n != 0 {
+		t.Errorf("CountryUUIDv8Into(-1) = %d, %v, expected ErrInvalidCountryCode", n, err)
+	}
+
+	g := NewGenerator(WithRand(bytes.NewReader(make([]byte, 16))))
+	if n, err := g.CountryUUIDv8Into(dst, countries.India); !errors.Is(err, ErrInsufficientEntropy) || n != 0 {
+		t.Errorf("CountryUUIDv8Into() with 16 random bytes = %d, %v, expected ErrInsufficientEntropy", n, err)
+	}
+}
+
+func TestAgeAt(t *testing.T) {
+	created := time.Date(2025, 3, 14, 15, 9, 26, 535*int(time.Millisecond), time.UTC)
+	u, _ := CountryUUIDv8At(countries.Portugal, created)
+
+	tests := []struct {
+		ref  time.Time
+		want time.Duration
+	}{
+		{created, 0},
+		{created.Add(90 * time.Minute), 90 * time.Minute},
+		{created.Add(-time.Second), -time.Second}, // clock skew
+	}
+
+	for _, tt := range tests {
+		got, err := AgeAt(u, tt.ref)
+		if err != nil {
+			t.Fatalf("AgeAt() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("AgeAt(%v) = %v, expected %v", tt.ref, got, tt.want)
+		}
+	}
+
+	if _, err := AgeAt(uuid.New(), created); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("AgeAt(v4) error = %v, expected ErrWrongVersion", err)
+	}
+	if _, err := Age(uuid.Nil); !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("Age(uuid.Nil) error = %v, expected ErrWrongVersion", err)
+	}
+}
+
+func TestAge(t *testing.T) {
+	u, _ := CountryUUIDv8At(countries.Portugal, time.Now().Add(-time.Hour))
+
+	age, err := Age(u)
+	if err != nil {
+		t.Fatalf("Age() error = %v", err)
+	}
+	if age < time.Hour || age > time.Hour+time.Minute {
+		t.Errorf("Age() = %v, expected about 1h", age)
+	}
+}
+
+func TestGetTimestampNanos(t *testing.T) {
+	resolution := time.Millisecond / fractionSteps
+
+	for _, nanos := range []int{0, 1, 244, 245, 123456, 999999, 999999999} {
+		created := time.Date(2024, 2, 29, 12, 0, 0, nanos, time.UTC)
+
+		u, err := CountryUUIDv8At(countries.Portugal, created)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8At() error = %v", err)
+		}
+
+		got := GetTimestampNanos(u)
+		if got.After(created) || created.Sub(got) > resolution {
+			t.Errorf("GetTimestampNanos() = %v, expected within %v below %v", got, resolution, created)
+		}
+
+		if want := created.Truncate(time.Millisecond); !GetTimestamp(u).Equal(want) {
+			t.Errorf("GetTimestamp() = %v, expected %v", GetTimestamp(u), want)
+		}
+	}
+}
+
 func TestCountryUUIDv8_RoundTrip(t *testing.T) {
 	// Test full cycle: creation -> extraction -> validation
 	allCountries := []countries.CountryCode{
@@ -187,15 +1181,18 @@ func TestCountryUUIDv8_RoundTrip(t *testing.T) {
 
 func TestCountryUUIDv8_TimestampProgression(t *testing.T) {
 	// Check that timestamp increases over time
-	u1, err := CountryUUIDv8(countries.Russia)
+	clock := newFakeClock()
+	g := NewGenerator(WithClock(clock))
+
+	u1, err := g.CountryUUIDv8(countries.Russia)
 	if err != nil {
 		t.Fatalf("CountryUUIDv8() error = %v", err)
 	}
 	time1 := GetTimestamp(u1)
 
-	time.Sleep(10 * time.Millisecond)
+	clock.now = clock.now.Add(10 * time.Millisecond)
 
-	u2, err := CountryUUIDv8(countries.Russia)
+	u2, err := g.CountryUUIDv8(countries.Russia)
 	if err != nil {
 		t.Fatalf("CountryUUIDv8() error = %v", err)
 	}
@@ -208,13 +1205,55 @@ func TestCountryUUIDv8_TimestampProgression(t *testing.T) {
 
 // Benchmarks for performance evaluation
 func BenchmarkCountryUUIDv8(b *testing.B) {
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		_, _ = CountryUUIDv8(countries.Russia)
 	}
 }
 
+func BenchmarkCountryUUIDv8Parallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = CountryUUIDv8(countries.Russia)
+		}
+	})
+}
+
+func BenchmarkCountryUUIDv8WithOptions(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = CountryUUIDv8(countries.Russia, WithNodeID(1), WithChecksum())
+	}
+}
+
+func BenchmarkCountryUUIDv8Loop100(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 100; j++ {
+			_, _ = CountryUUIDv8(countries.Russia)
+		}
+	}
+}
+
+func BenchmarkCountryUUIDv8Batch100(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = CountryUUIDv8Batch(countries.Russia, 100)
+	}
+}
+
+func BenchmarkCountryUUIDv8Into100(b *testing.B) {
+	dst := make([]uuid.UUID, 100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = CountryUUIDv8Into(dst, countries.Russia)
+	}
+}
+
 func BenchmarkExtractCountry(b *testing.B) {
 	u, _ := CountryUUIDv8(countries.Russia)
+	b.ReportAllocs()
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
@@ -222,6 +1261,39 @@ func BenchmarkExtractCountry(b *testing.B) {
 	}
 }
 
+// The batch benchmarks decode the same page of 1000 UUIDs into a reused
+// buffer, so they measure decoding only and stay allocation-free. The loop
+// variant is the naive per-UUID ExtractCountry call it is compared against.
+func benchmarkPage(b *testing.B) ([]uuid.UUID, []countries.CountryCode) {
+	page, err := CountryUUIDv8RoundRobin(countries.All(), 1000)
+	if err != nil {
+		b.Fatalf("CountryUUIDv8RoundRobin() error = %v", err)
+	}
+	return page, make([]countries.CountryCode, len(page))
+}
+
+func BenchmarkExtractCountriesLoop(b *testing.B) {
+	page, buf := benchmarkPage(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j, u := range page {
+			buf[j], _ = ExtractCountry(u)
+		}
+	}
+}
+
+func BenchmarkExtractCountriesBatch(b *testing.B) {
+	page, buf := benchmarkPage(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ExtractCountriesInto(buf, page)
+	}
+}
+
 func BenchmarkGetTimestamp(b *testing.B) {
 	u, _ := CountryUUIDv8(countries.Russia)
 	b.ResetTimer()
@@ -231,6 +1303,41 @@ func BenchmarkGetTimestamp(b *testing.B) {
 	}
 }
 
+func TestCountryUUIDv8_InvalidCountryCode(t *testing.T) {
+	// Every code the concurrency test uses, plus the edges of the field
+	var valid []countries.CountryCode
+	for i := 0; i < 250; i++ {
+		valid = append(valid, countries.CountryCode(i))
+	}
+	valid = append(valid, countries.Kosovo, countries.None, countries.NonCountryInmarsat, countries.CountryCode(RegionCodeBase-1))
+
+	for _, country := range valid {
+		u, err := CountryUUIDv8(country)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8(%d) error = %v", country, err)
+		}
+		if got := MustExtractCountry(u); got != country {
+			t.Errorf("ExtractCountry() = %d, expected %d", got, country)
+		}
+	}
+
+	invalid := []countries.CountryCode{
+		-1,
+		countries.CountryCode(RegionCodeBase),
+		countries.CountryCode(1<<CountryBitWidth - 1),
+		countries.CountryCode(1 << CountryBitWidth),
+		countries.CountryCode(1 << 32),
+	}
+	for _, country := range invalid {
+		if _, err := CountryUUIDv8(country); !errors.Is(err, ErrInvalidCountryCode) {
+			t.Errorf("CountryUUIDv8(%d) error = %v, expected ErrInvalidCountryCode", country, err)
+		}
+		if _, err := CountryUUIDv8Batch(country, 2); !errors.Is(err, ErrInvalidCountryCode) {
+			t.Errorf("CountryUUIDv8Batch(%d) error = %v, expected ErrInvalidCountryCode", country, err)
+		}
+	}
+}
+
 // Test concurrent generation
 func TestCountryUUIDv8_Concurrent(t *testing.T) {
 	const goroutines = 100
@@ -0,0 +1,187 @@
+package uuidv8country
+
+import (
+	"fmt"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+// Zero is the zero UUID, uuid.Nil, for marking ids that are not set yet.
+var Zero = uuid.Nil
+
+// IsZero reports whether u is the zero UUID. No UUID generated by this package
+// is zero.
+func IsZero(u uuid.UUID) bool {
+	return u == Zero
+}
+
+// Validate checks that u is a well-formed country UUID v8.
+//
+// It confirms the version and variant fields and that the embedded country code
+// decodes to a country known to the countries package. The returned error wraps
+// one of ErrWrongVersion, ErrWrongVariant or ErrUnknownCountry, so callers can
+// branch on the failure mode with errors.Is:
+//
+//	if err := Validate(u); errors.Is(err, ErrUnknownCountry) {
+//		// well-formed, but from a foreign generator
+//	}
+//
+// Note that UUIDs generated for countries.Unknown fail with ErrUnknownCountry,
+// continent-only UUIDs generated by ContinentUUIDv8 with ErrContinentOnly, and
+// the zero UUID with ErrZeroUUID.
+func Validate(u uuid.UUID) error {
+	if IsZero(u) {
+		return ErrZeroUUID
+	}
+
+	if err := checkVersionVariant(u); err != nil {
+		return err
+	}
+
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return err
+	}
+
+	if !country.IsValid() {
+		return fmt.Errorf("%w: code %d", ErrUnknownCountry, int64(country))
+	}
+
+	return nil
+}
+
+// ValidateStrict is like Validate but also fails with ErrReservedBitsSet if the
+// reserved bit 67 or, when the extension flag is set, one of the
+// ReservedOptionFlags in byte 11 is set. UUIDs generated by this package never
+// set them, so a UUID failing only this check was either tampered with or
+// produced by a newer version of the package defining these bits.
+func ValidateStrict(u uuid.UUID) error {
+	if err := Validate(u); err != nil {
+		return err
+	}
+
+	if u[ReservedBitOffset/8]&reservedBit != 0 {
+		return fmt.Errorf("%w: bit %d", ErrReservedBitsSet, ReservedBitOffset)
+	}
+
+	if flags := optionFlags(u) & ReservedOptionFlags; flags != 0 {
+		return fmt.Errorf("%w: option flags %#02x", ErrReservedBitsSet, flags)
+	}
+
+	return nil
+}
+
+// IsCountryUUIDv8 reports whether u passes Validate.
+func IsCountryUUIDv8(u uuid.UUID) bool {
+	return Validate(u) == nil
+}
+
+// HasKnownCountry reports whether u is version 8 and its country bits hold the
+// code of an actual country in the countries package.
+//
+// It is stricter than Validate: besides countries.Unknown and codes the
+// countries package does not recognize, it also rejects the package's
+// placeholder and non-country codes such as countries.None,
+// countries.International and the telephony-only codes, as well as UUIDs with
+// the reserved layout bit set. Since the country field is 20 bits wide and only
+// 252 codes qualify, a UUID from a foreign v8 generator passes by accident only
+// about once in 11000 (252/2^20 for the code, times 1/2 for the reserved bit
+// and 3/4 for not looking continent-only), which makes this a cheap way to tell
+// them apart from UUIDs generated by this package.
+func HasKnownCountry(u uuid.UUID) bool {
+	country, err := ExtractCountry(u)
+	if err != nil {
+		return false
+	}
+
+	if u[ReservedBitOffset/8]&reservedBit != 0 {
+		return false
+	}
+
+	return isRealCountry(country)
+}
+
+// IsDeprecatedCountry reports whether c is one of the codes the countries
+// package keeps for countries that no longer exist: countries.NetherlandsAntilles
+// (530, dissolved in 2010 into Curacao, Sint Maarten and the Caribbean
+// Netherlands) and countries.Yugoslavia (891, Serbia and Montenegro, split in
+// 2006).
+//
+// These codes are still accepted everywhere, since historical data may
+// legitimately refer to them: CountryUUIDv8 embeds them, even WithStrictCountry,
+// and ExtractCountry, Validate and HasKnownCountry treat them like any other
+// country. Consumers that want to flag or remap such records check the
+// extracted country with IsDeprecatedCountry. Withdrawn codes the countries
+// package does not know at all, such as 810 for the Soviet Union, are unknown
+// countries instead.
+func IsDeprecatedCountry(c countries.CountryCode) bool {
+	switch c {
+	case countries.NetherlandsAntilles, countries.Yugoslavia:
+		return true
+	}
+	return false
+}
+
+// isRealCountry reports whether c names an actual country. All of them have
+// codes below countries.None, the first of the package's placeholder codes.
+func isRealCountry(c countries.CountryCode) bool {
+	return c < countries.None && c.IsValid()
+}
+
+// Version returns the value of the 4-bit version field of u, which is 8 for
+// UUIDs generated by this package. Unlike uuid.UUID.Version it does not depend
+// on the variant.
+func Version(u uuid.UUID) int {
+	return int(u[6] >> 4)
+}
+
+// IsRFC4122Variant reports whether u carries the RFC 4122 variant, binary 10
+// in the top bits of byte 8, as every UUID generated by this package does.
+func IsRFC4122Variant(u uuid.UUID) bool {
+	return u[8]&0xc0 == 0x80
+}
+
+// IsMonotonic reports whether u is a UUID v8 from a generator created
+// WithMonotonic or WithPerCountryCounter, whose sub-millisecond fraction is a
+// counter rather than taken from the clock. UUIDs from such a generator are
+// strictly ordered among themselves, while for other UUIDs generated within the
+// same 1/4096 ms only the random bits tell them apart, which matters for
+// deduplication that assumes ties are rare.
+//
+// It reads the MonotonicFlag option flag, so it is false for UUIDs from other
+// generators, including this package's versions before the flag was added.
+func IsMonotonic(u uuid.UUID) bool {
+	return Version(u) == 8 && IsRFC4122Variant(u) && optionFlags(u)&MonotonicFlag != 0
+}
+
+// isStorableCountry reports whether c fits the country field outside the range
+// reserved for regions.
+func isStorableCountry(c countries.CountryCode) bool {
+	return c >= 0 && c < RegionCodeBase
+}
+
+// ComplianceCheck reports whether u is a well-formed UUIDv8 under RFC 9562,
+// section 5.8, which constrains only the version (bits 48-51, binary 1000) and
+// the variant (bits 64-65, binary 10) and leaves the other 122 bits to the
+// implementation. Every UUID this package generates passes, whatever its
+// country and options, and so does any other v8 UUID: unlike Validate, it does
+// not look at the country field or any other part of this package's layout.
+//
+// Returns an error wrapping ErrWrongVersion or ErrWrongVariant.
+func ComplianceCheck(u uuid.UUID) error {
+	return checkVersionVariant(u)
+}
+
+// checkVersionVariant reports whether u carries version 8 and the RFC 4122 variant.
+func checkVersionVariant(u uuid.UUID) error {
+	if version := Version(u); version != 8 {
+		return versionErrors[version]
+	}
+
+	if !IsRFC4122Variant(u) {
+		return fmt.Errorf("%w: variant %02b", ErrWrongVariant, u[8]>>6)
+	}
+
+	return nil
+}
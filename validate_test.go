@@ -0,0 +1,376 @@
+package uuidv8country
+
+import (
+	"errors"
+	mrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/biter777/countries"
+	"github.com/google/uuid"
+)
+
+func TestValidate(t *testing.T) {
+	valid, _ := CountryUUIDv8(countries.Mexico)
+	unknown, _ := CountryUUIDv8(countries.Unknown)
+	outOfRange, _ := CountryUUIDv8(countries.CountryCode(1))
+
+	wrongVariant := valid
+	wrongVariant[8] = (wrongVariant[8] & 0x3f) | 0xc0
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want error
+	}{
+		{"Valid", valid, nil},
+		{"Version4", uuid.New(), ErrWrongVersion},
+		{"Nil", uuid.Nil, ErrWrongVersion},
+		{"Zero", Zero, ErrZeroUUID},
+		{"WrongVariant", wrongVariant, ErrWrongVariant},
+		{"UnknownCountry", unknown, ErrUnknownCountry},
+		{"OutOfRangeCountry", outOfRange, ErrUnknownCountry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.u)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, expected nil", err)
+				}
+			} else if !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, expected %v", err, tt.want)
+			}
+
+			if got := IsCountryUUIDv8(tt.u); got != (tt.want == nil) {
+				t.Errorf("IsCountryUUIDv8() = %v, expected %v", got, tt.want == nil)
+			}
+		})
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Unknown)
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want bool
+	}{
+		{"Zero", Zero, true},
+		{"Nil", uuid.Nil, true},
+		{"UnknownCountry", u, false},
+		{"Version4", uuid.New(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsZero(tt.u); got != tt.want {
+				t.Errorf("IsZero() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_ZeroIsDistinct(t *testing.T) {
+	if err := Validate(uuid.New()); errors.Is(err, ErrZeroUUID) {
+		t.Errorf("Validate() error = %v, expected not ErrZeroUUID", err)
+	}
+}
+
+func TestHasKnownCountry(t *testing.T) {
+	for _, country := range countries.All() {
+		u, err := CountryUUIDv8(country)
+		if err != nil {
+			t.Fatalf("CountryUUIDv8() error = %v", err)
+		}
+		if !HasKnownCountry(u) {
+			t.Errorf("HasKnownCountry() = false for %v (code: %d)", country, country)
+		}
+	}
+
+	notCountries := []countries.CountryCode{
+		countries.Unknown,
+		countries.None,
+		countries.International,
+		countries.NonCountryInmarsat,
+		countries.CountryCode(1),
+		countries.CountryCode(RegionCodeBase - 1),
+	}
+	for _, country := range notCountries {
+		u, _ := CountryUUIDv8(country)
+		if HasKnownCountry(u) {
+			t.Errorf("HasKnownCountry() = true for code %d", country)
+		}
+	}
+
+	if HasKnownCountry(uuid.New()) {
+		t.Error("HasKnownCountry() = true for a v4 UUID")
+	}
+}
+
+func TestHasKnownCountry_ForeignV8(t *testing.T) {
+	// Random v4 bytes relabelled as v8 model UUIDs from other v8 generators.
+	// They pass with probability 252/2^20 for the code, times 1/2 for a clear
+	// reserved bit and 3/4 for no region flag, 3*252/2^23, so expect about 9 in
+	// 100000.
+	rng := mrand.New(mrand.NewSource(1))
+
+	const n = 100000
+	accepted := 0
+	for i := 0; i < n; i++ {
+		var u uuid.UUID
+		rng.Read(u[:])
+		u[6] = (u[6] & 0x0f) | 0x80
+		u[8] = (u[8] & 0x3f) | 0x80
+
+		if HasKnownCountry(u) {
+			accepted++
+		}
+	}
+
+	if accepted > 30 {
+		t.Errorf("HasKnownCountry() accepted %d of %d foreign UUIDs, expected about 9", accepted, n)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	v8, _ := CountryUUIDv8(countries.Qatar)
+	v7, _ := uuid.NewV7()
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want int
+	}{
+		{"V8", v8, 8},
+		{"V7", v7, 7},
+		{"V4", uuid.New(), 4},
+		{"Nil", uuid.Nil, 0},
+		{"Max", uuid.Max, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Version(tt.u); got != tt.want {
+				t.Errorf("Version() = %d, expected %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRFC4122Variant(t *testing.T) {
+	u, _ := CountryUUIDv8(countries.Qatar)
+	if !IsRFC4122Variant(u) {
+		t.Error("IsRFC4122Variant() = false for a generated UUID")
+	}
+
+	// NCS, Microsoft and future variants
+	for _, variantBits := range []byte{0x00, 0x40, 0xc0} {
+		u[8] = u[8]&0x3f | variantBits
+		if IsRFC4122Variant(u) {
+			t.Errorf("IsRFC4122Variant() = true for byte 8 = %#x", u[8])
+		}
+	}
+}
+
+func TestIsMonotonic(t *testing.T) {
+	monotonic := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithMonotonic())
+	perCountry := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithPerCountryCounter())
+	random := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))))
+
+	sequenced, _ := monotonic.CountryUUIDv8(countries.Mexico)
+	withOptions, _ := monotonic.CountryUUIDv8(countries.Mexico, WithNodeID(5), WithChecksum())
+	batch, _ := monotonic.CountryUUIDv8Batch(countries.Mexico, 2)
+	atTime, _ := monotonic.CountryUUIDv8At(countries.Mexico, time.Now())
+	byCountry, _ := perCountry.CountryUUIDv8(countries.Mexico)
+	plain, _ := random.CountryUUIDv8(countries.Mexico)
+	plainOptions, _ := random.CountryUUIDv8(countries.Mexico, WithNodeID(5))
+	rewritten, _ := WithTimestamp(sequenced, time.Now())
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want bool
+	}{
+		{"Monotonic", sequenced, true},
+		{"MonotonicWithOptions", withOptions, true},
+		{"MonotonicBatch", batch[1], true},
+		{"PerCountryCounter", byCountry, true},
+		{"MonotonicAt", atTime, false},
+		{"Random", plain, false},
+		{"RandomWithOptions", plainOptions, false},
+		{"WithTimestamp", rewritten, false},
+		{"Version4", uuid.New(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMonotonic(tt.u); got != tt.want {
+				t.Errorf("IsMonotonic() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+
+	// The flag leaves the other fields intact and is not reserved
+	if id, _ := ExtractNodeID(withOptions); id != 5 || !VerifyChecksum(withOptions) {
+		t.Errorf("ExtractNodeID() = %d, VerifyChecksum() = %v, expected 5, true", id, VerifyChecksum(withOptions))
+	}
+	if err := ValidateStrict(sequenced); err != nil {
+		t.Errorf("ValidateStrict() error = %v", err)
+	}
+}
+
+func TestComplianceCheck_Vectors(t *testing.T) {
+	tests := []struct {
+		name string
+		u    string
+		want error
+	}{
+		// RFC 9562, appendix B.1 and B.2
+		{"RFC9562TimeBased", "2489e9ad-2ee2-8e00-8ec9-32d5f69181c0", nil},
+		{"RFC9562NameBased", "5c146b14-3c52-8afd-938a-375d0df1fbf6", nil},
+		// Only the version and variant bits are fixed
+		{"AllFreeBitsZero", "00000000-0000-8000-8000-000000000000", nil},
+		{"AllFreeBitsOne", "ffffffff-ffff-8fff-bfff-ffffffffffff", nil},
+
+		// RFC 9562, appendix A.3 (v4) and A.6 (v7)
+		{"RFC9562Version4", "919108f7-52d1-4320-9bac-f847db4148a8", ErrWrongVersion},
+		{"RFC9562Version7", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", ErrWrongVersion},
+		{"Nil", "00000000-0000-0000-0000-000000000000", ErrWrongVersion},
+		{"Max", "ffffffff-ffff-ffff-ffff-ffffffffffff", ErrWrongVersion},
+		{"VariantNCS", "2489e9ad-2ee2-8e00-0ec9-32d5f69181c0", ErrWrongVariant},
+		{"VariantMicrosoft", "2489e9ad-2ee2-8e00-cec9-32d5f69181c0", ErrWrongVariant},
+		{"VariantFuture", "2489e9ad-2ee2-8e00-eec9-32d5f69181c0", ErrWrongVariant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ComplianceCheck(uuid.MustParse(tt.u))
+			if tt.want == nil && err != nil {
+				t.Errorf("ComplianceCheck(%s) error = %v, expected nil", tt.u, err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("ComplianceCheck(%s) error = %v, expected %v", tt.u, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplianceCheck_Generated(t *testing.T) {
+	g := NewGenerator(WithRand(mrand.New(mrand.NewSource(1))), WithMonotonic())
+
+	var us []uuid.UUID
+	for _, opts := range [][]Option{
+		nil,
+		{WithNodeID(0xff), WithChecksum()},
+		{WithPayload(1<<64 - 1)},
+		{WithReservedZero()},
+	} {
+		for _, country := range []countries.CountryCode{countries.Unknown, countries.Mexico, countries.NonCountryInmarsat, RegionCodeBase - 1} {
+			u, err := g.CountryUUIDv8(country, opts...)
+			if err != nil {
+				t.Fatalf("CountryUUIDv8(%d) error = %v", country, err)
+			}
+			us = append(us, u)
+		}
+	}
+	continent, _ := g.ContinentUUIDv8(countries.RegionEU)
+	deterministic := CountryUUIDv8Deterministic(countries.Mexico, []byte("key"))
+	converted, _ := FromUUIDv7(uuid.MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f"), countries.Mexico)
+	us = append(us, continent, deterministic, converted)
+
+	for _, u := range us {
+		if err := ComplianceCheck(u); err != nil {
+			t.Errorf("ComplianceCheck(%s) error = %v", u, err)
+		}
+	}
+}
+
+func TestValidateStrict(t *testing.T) {
+	plain, _ := CountryUUIDv8(countries.Oman, WithReservedZero())
+	withOptions, _ := CountryUUIDv8(countries.Oman, WithReservedZero(), WithNodeID(2))
+
+	reservedBitSet := plain
+	reservedBitSet[ReservedBitOffset/8] |= reservedBit
+
+	reservedFlagSet := withOptions
+	reservedFlagSet[OptionsByteOffset] |= 0x01
+
+	// Without the extension flag byte 11 is random and not checked
+	randomByte11 := plain
+	randomByte11[OptionsByteOffset] = 0xff
+
+	tests := []struct {
+		name string
+		u    uuid.UUID
+		want error
+	}{
+		{"Plain", plain, nil},
+		{"WithOptions", withOptions, nil},
+		{"RandomByte11", randomByte11, nil},
+		{"ReservedBit", reservedBitSet, ErrReservedBitsSet},
+		{"ReservedFlag", reservedFlagSet, ErrReservedBitsSet},
+		{"Version4", uuid.New(), ErrWrongVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStrict(tt.u)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("ValidateStrict() error = %v, expected nil", err)
+				}
+			} else if !errors.Is(err, tt.want) {
+				t.Errorf("ValidateStrict() error = %v, expected %v", err, tt.want)
+			}
+		})
+	}
+
+	// Tampering only with reserved bits still passes the lenient check
+	if err := Validate(reservedBitSet); err != nil {
+		t.Errorf("Validate() error = %v, expected nil", err)
+	}
+}
+
+func TestIsDeprecatedCountry(t *testing.T) {
+	tests := []struct {
+		country countries.CountryCode
+		want    bool
+	}{
+		{countries.NetherlandsAntilles, true},
+		{countries.Yugoslavia, true},
+		{countries.Curacao, false},
+		{countries.Serbia, false},
+		{countries.Montenegro, false},
+		{countries.Unknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country.String(), func(t *testing.T) {
+			if got := IsDeprecatedCountry(tt.country); got != tt.want {
+				t.Errorf("IsDeprecatedCountry() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecatedCountry_RoundTrip(t *testing.T) {
+	for _, country := range []countries.CountryCode{countries.NetherlandsAntilles, countries.Yugoslavia} {
+		u, err := CountryUUIDv8(country, WithStrictCountry())
+		if err != nil {
+			t.Fatalf("CountryUUIDv8(%v) error = %v", country, err)
+		}
+
+		if got := MustExtractCountry(u); got != country {
+			t.Errorf("ExtractCountry() = %v, expected %v", got, country)
+		}
+		if err := Validate(u); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+		if !HasKnownCountry(u) {
+			t.Errorf("HasKnownCountry() = false for %v", country)
+		}
+	}
+}